@@ -11,7 +11,61 @@ type Config struct {
 	REDISHOST      string `mapstructure:"REDISHOST"`
 	REDIS_PASSWORD string `mapstructure:"REDIS_PASSWORD"`
 	SECERETKEY     string `mapstructure:"JWTKEY"`
-	PORT           string `mapstructure:"PORT"`
+	// JSON key set file for key rotation and RS256/EdDSA, see utility.LoadKeySet
+	JWT_KEYS_FILE string `mapstructure:"JWT_KEYS_FILE"`
+	// iss and aud claims of issued tokens and the clock skew tolerated when checking them
+	JWT_ISSUER             string `mapstructure:"JWT_ISSUER"`
+	JWT_AUDIENCE           string `mapstructure:"JWT_AUDIENCE"`
+	JWT_CLOCK_SKEW_SECONDS int    `mapstructure:"JWT_CLOCK_SKEW_SECONDS"`
+	PORT                   string `mapstructure:"PORT"`
+	// Base URL used to build the links mailed to users
+	APP_BASE_URL string `mapstructure:"APP_BASE_URL"`
+	// SMTP relay; when SMTP_HOST is empty mails are written to the log instead
+	SMTP_HOST     string `mapstructure:"SMTP_HOST"`
+	SMTP_PORT     string `mapstructure:"SMTP_PORT"`
+	SMTP_USERNAME string `mapstructure:"SMTP_USERNAME"`
+	SMTP_PASSWORD string `mapstructure:"SMTP_PASSWORD"`
+	MAIL_FROM     string `mapstructure:"MAIL_FROM"`
+	// Failed logins per username before a temporary lockout, and its length
+	LOGIN_MAX_ATTEMPTS    int `mapstructure:"LOGIN_MAX_ATTEMPTS"`
+	LOGIN_LOCKOUT_MINUTES int `mapstructure:"LOGIN_LOCKOUT_MINUTES"`
+	// Subtask levels allowed below a top level task, and whether a task can only
+	// be completed once all its subtasks are
+	SUBTASK_MAX_DEPTH               int  `mapstructure:"SUBTASK_MAX_DEPTH"`
+	SUBTASK_BLOCK_PARENT_COMPLETION bool `mapstructure:"SUBTASK_BLOCK_PARENT_COMPLETION"`
+	// Days deleted tasks stay in the trash before they are purged, 0 keeps them
+	TRASH_RETENTION_DAYS int `mapstructure:"TRASH_RETENTION_DAYS"`
+	// Where attachments are stored: "local" below ATTACHMENT_DIR or "s3" in S3_BUCKET
+	ATTACHMENT_STORE string `mapstructure:"ATTACHMENT_STORE"`
+	ATTACHMENT_DIR   string `mapstructure:"ATTACHMENT_DIR"`
+	// Largest upload, comma separated allowed content types and storage per user
+	ATTACHMENT_MAX_MB   int    `mapstructure:"ATTACHMENT_MAX_MB"`
+	ATTACHMENT_TYPES    string `mapstructure:"ATTACHMENT_TYPES"`
+	ATTACHMENT_QUOTA_MB int    `mapstructure:"ATTACHMENT_QUOTA_MB"`
+	// S3 compatible bucket; S3_PATH_STYLE is needed for MinIO and similar stand-ins
+	S3_ENDPOINT   string `mapstructure:"S3_ENDPOINT"`
+	S3_BUCKET     string `mapstructure:"S3_BUCKET"`
+	S3_REGION     string `mapstructure:"S3_REGION"`
+	S3_ACCESS_KEY string `mapstructure:"S3_ACCESS_KEY"`
+	S3_SECRET_KEY string `mapstructure:"S3_SECRET_KEY"`
+	S3_PATH_STYLE bool   `mapstructure:"S3_PATH_STYLE"`
+	// Minutes between due date reminder runs, 0 turns reminders off
+	REMINDER_INTERVAL_MINUTES int `mapstructure:"REMINDER_INTERVAL_MINUTES"`
+	// Comma separated default reminders in minutes before the due date, and how
+	// long after the due date the overdue reminder is still sent
+	REMINDER_BEFORE_MINUTES    string `mapstructure:"REMINDER_BEFORE_MINUTES"`
+	REMINDER_MAX_OVERDUE_HOURS int    `mapstructure:"REMINDER_MAX_OVERDUE_HOURS"`
+	// Comma separated channels reminders go out on: inapp, email and webhook
+	REMINDER_CHANNELS    string `mapstructure:"REMINDER_CHANNELS"`
+	REMINDER_WEBHOOK_URL string `mapstructure:"REMINDER_WEBHOOK_URL"`
+	// Seconds between webhook delivery runs, 0 turns webhook subscriptions off
+	WEBHOOK_INTERVAL_SECONDS int `mapstructure:"WEBHOOK_INTERVAL_SECONDS"`
+	// Attempts before a delivery is given up, and the timeout of each
+	WEBHOOK_MAX_ATTEMPTS    int `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WEBHOOK_TIMEOUT_SECONDS int `mapstructure:"WEBHOOK_TIMEOUT_SECONDS"`
+	// Comma separated CIDRs of trusted internal receivers, other non-public
+	// addresses are refused
+	WEBHOOK_ALLOWED_NETWORKS string `mapstructure:"WEBHOOK_ALLOWED_NETWORKS"`
 }
 
 func LoadConfig() *Config {
@@ -27,6 +81,27 @@ func LoadConfig() *Config {
 	}
 
 	viper.AutomaticEnv()
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS", 10)
+	viper.SetDefault("LOGIN_LOCKOUT_MINUTES", 15)
+	viper.SetDefault("JWT_ISSUER", "task-mgt")
+	viper.SetDefault("JWT_AUDIENCE", "task-mgt-api")
+	viper.SetDefault("JWT_CLOCK_SKEW_SECONDS", 30)
+	viper.SetDefault("SUBTASK_MAX_DEPTH", 5)
+	viper.SetDefault("SUBTASK_BLOCK_PARENT_COMPLETION", true)
+	viper.SetDefault("TRASH_RETENTION_DAYS", 30)
+	viper.SetDefault("ATTACHMENT_STORE", "local")
+	viper.SetDefault("ATTACHMENT_DIR", "./data/attachments")
+	viper.SetDefault("ATTACHMENT_MAX_MB", 10)
+	viper.SetDefault("ATTACHMENT_TYPES", "image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain")
+	viper.SetDefault("ATTACHMENT_QUOTA_MB", 100)
+	viper.SetDefault("S3_REGION", "us-east-1")
+	viper.SetDefault("REMINDER_INTERVAL_MINUTES", 5)
+	viper.SetDefault("REMINDER_BEFORE_MINUTES", "1440")
+	viper.SetDefault("REMINDER_MAX_OVERDUE_HOURS", 24)
+	viper.SetDefault("REMINDER_CHANNELS", "inapp")
+	viper.SetDefault("WEBHOOK_INTERVAL_SECONDS", 5)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_TIMEOUT_SECONDS", 10)
 
 	err = viper.Unmarshal(&config)
 	if err != nil {
@@ -59,8 +59,14 @@ func SetupRedis(cfg *Config) (*RedisService, error) {
 // 	}, nil
 // }
 
+// errRedisUnavailable is returned by the helpers below when no client is configured.
+var errRedisUnavailable = errors.New("redis is not configured")
+
 // SetDataInRedis will set  data in redis with a key and expiration time.
 func (r *RedisService) SetDataInRedis(key string, value []byte, expTime time.Duration) error {
+	if r == nil || r.Client == nil {
+		return errRedisUnavailable
+	}
 	err := r.Client.Set(context.Background(), key, value, expTime).Err()
 	if err != nil {
 		return err
@@ -70,6 +76,9 @@ func (r *RedisService) SetDataInRedis(key string, value []byte, expTime time.Dur
 
 // GetFromRedis will help to retrieve the data from redis.
 func (r *RedisService) GetFromRedis(key string) (string, error) {
+	if r == nil || r.Client == nil {
+		return "", errRedisUnavailable
+	}
 	jsonData, err := r.Client.Get(context.Background(), key).Result()
 	if err != nil {
 		return "", err
@@ -79,6 +88,9 @@ func (r *RedisService) GetFromRedis(key string) (string, error) {
 
 // DeleteFromRedis will help to delet the data from redis
 func (r *RedisService) DeleteFromRedis(key string) error {
+	if r == nil || r.Client == nil {
+		return errRedisUnavailable
+	}
 	ctx := context.Background()
 	err := r.Client.Del(ctx, key).Err()
 	if err != nil {
@@ -87,3 +99,19 @@ func (r *RedisService) DeleteFromRedis(key string) error {
 	return nil
 
 }
+
+// DeleteByPattern removes every key matching the given glob pattern.
+// It walks the keyspace with SCAN so it does not block redis like KEYS would.
+func (r *RedisService) DeleteByPattern(pattern string) error {
+	if r == nil || r.Client == nil {
+		return errRedisUnavailable
+	}
+	ctx := context.Background()
+	iter := r.Client.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		if err := r.Client.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,18 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no blob is stored under the key
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps the contents of uploaded files. Keys are chosen by the caller and
+// made of path segments such as "tasks/12/3f9a".
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
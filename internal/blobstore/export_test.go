@@ -0,0 +1,11 @@
+package blobstore
+
+import (
+	"net/http"
+	"time"
+)
+
+// Sign exposes the request signer to the tests
+func (s *S3Store) Sign(req *http.Request, payloadHash string, now time.Time) {
+	s.sign(req, payloadHash, now)
+}
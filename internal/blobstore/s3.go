@@ -0,0 +1,252 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unsignedPayload lets uploads stream without hashing the body first
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// emptyPayloadHash is the SHA-256 of an empty body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// S3Config locates a bucket of an S3 compatible service such as AWS S3 or MinIO
+type S3Config struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.eu-west-1.amazonaws.com
+	// or http://localhost:9000
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket as endpoint/bucket/key instead of
+	// bucket.endpoint/key, MinIO and most stand-ins need it
+	PathStyle bool
+}
+
+// S3Store keeps blobs as objects of a bucket, signing requests with AWS Signature Version 4
+type S3Store struct {
+	cfg    S3Config
+	base   *url.URL
+	Client *http.Client
+	// now is replaced in tests
+	now func() time.Time
+}
+
+// NewS3Store creates a store for the bucket described by cfg
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	base, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || base.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &S3Store{
+		cfg:    cfg,
+		base:   base,
+		Client: &http.Client{Timeout: 5 * time.Minute},
+		now:    time.Now,
+	}, nil
+}
+
+// objectURL is the URL of the object stored under key
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.base
+	prefix := u.Path
+	if s.cfg.PathStyle {
+		prefix += "/" + s.cfg.Bucket
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+	}
+	u.Path = prefix + "/" + key
+	u.RawPath = escapePath(u.Path)
+	return &u
+}
+
+// do signs and sends a request for the object stored under key
+func (s *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64, header http.Header, payloadHash string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	s.sign(req, payloadHash, s.now().UTC())
+	return s.Client.Do(req)
+}
+
+// Put implements BlobStore
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, header, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp, "put", key)
+	}
+	return nil
+}
+
+// Get implements BlobStore
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0, nil, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error(resp, "get", key)
+	}
+	return resp.Body, nil
+}
+
+// Delete implements BlobStore. S3 answers 204 whether the object existed or not.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, nil, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error(resp, "delete", key)
+	}
+	return nil
+}
+
+// s3Error reports a failed request with the start of the error document
+func s3Error(resp *http.Response, op, key string) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s: %s: %s", op, key, resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// sign adds the AWS Signature Version 4 headers to the request. The host, content
+// type, range and x-amz-* headers are signed.
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || lower == "range" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery sorts and escapes the query parameters as SigV4 expects
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapePath escapes every segment of an object key, keeping the slashes
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes everything but the unreserved characters of RFC 3986
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
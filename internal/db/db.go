@@ -29,10 +29,39 @@ func ConnectDB(config *config.Config) *gorm.DB {
 	}
 
 	// Migrate the schema
-	if err := DB.AutoMigrate(&models.Users{}, &models.Task{}); err != nil {
+	if err := DB.AutoMigrate(&models.Users{}, &models.Task{}, &models.RecoveryCode{}, &models.APIKey{},
+		&models.Project{}, &models.ProjectMember{}, &models.TaskAssignee{},
+		&models.Label{}, &models.TaskLabel{}, &models.TaskDependency{},
+		&models.WorkflowStatus{}, &models.WorkflowTransition{}, &models.TaskHistory{},
+		&models.Comment{}, &models.CommentEdit{}, &models.Attachment{},
+		&models.Notification{}, &models.NotificationPreference{}, &models.TaskReminder{},
+		&models.WebhookSubscription{}, &models.WebhookDelivery{}); err != nil {
 		log.Printf("Error while migrating: %v", err)
 		return nil
 	}
 
+	if err := seedWorkflow(DB); err != nil {
+		log.Printf("Error while seeding the workflow: %v", err)
+		return nil
+	}
+
 	return DB
 }
+
+// seedWorkflow installs models.DefaultWorkflow when no workflow is configured yet
+func seedWorkflow(DB *gorm.DB) error {
+	var count int64
+	if err := DB.Model(&models.WorkflowStatus{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	workflow := models.DefaultWorkflow()
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&workflow.Statuses).Error; err != nil {
+			return err
+		}
+		return tx.Create(&workflow.Transitions).Error
+	})
+}
@@ -1,12 +1,21 @@
 package di
 
 import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/ratheeshkumar25/task-mgt/config"
+	"github.com/ratheeshkumar25/task-mgt/internal/blobstore"
 	"github.com/ratheeshkumar25/task-mgt/internal/db"
 	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mailer"
+	"github.com/ratheeshkumar25/task-mgt/internal/notifier"
 	"github.com/ratheeshkumar25/task-mgt/internal/repositories"
 	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/ratheeshkumar25/task-mgt/internal/webhook"
 	"github.com/ratheeshkumar25/task-mgt/utility"
 )
 
@@ -23,22 +32,148 @@ func Init() {
 		log.Fatalf("failed to connect to redis: %v", err)
 	}
 
+	// Initialize signing keys, the JWTKEY secret alone unless a key set file is configured
+	keys := utility.NewHMACKeySet(cfg.SECERETKEY)
+	if cfg.JWT_KEYS_FILE != "" {
+		keys, err = utility.LoadKeySet(cfg.JWT_KEYS_FILE, cfg.SECERETKEY)
+		if err != nil {
+			log.Fatalf("failed to load jwt keys: %v", err)
+		}
+	}
+	keys.SetClaimsPolicy(utility.ClaimsPolicy{
+		Issuer:   cfg.JWT_ISSUER,
+		Audience: cfg.JWT_AUDIENCE,
+		Leeway:   time.Duration(cfg.JWT_CLOCK_SKEW_SECONDS) * time.Second,
+	})
+
 	// Initialize Database
 	dbConn := db.ConnectDB(cfg)
 
 	// Initialize Repository
 	taskRepo := repositories.NewTaskRepository(dbConn)
 
+	// Initialize Mailer, falling back to the log when no SMTP relay is configured
+	var mail mailer.Mailer = mailer.NewLogMailer(log)
+	if cfg.SMTP_HOST != "" {
+		mail = mailer.NewSMTPMailer(cfg.SMTP_HOST, cfg.SMTP_PORT, cfg.SMTP_USERNAME, cfg.SMTP_PASSWORD, cfg.MAIL_FROM)
+	}
+
+	// Initialize Blob Store for attachments
+	var blobs blobstore.BlobStore
+	if cfg.ATTACHMENT_STORE == "s3" {
+		blobs, err = blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:  cfg.S3_ENDPOINT,
+			Bucket:    cfg.S3_BUCKET,
+			Region:    cfg.S3_REGION,
+			AccessKey: cfg.S3_ACCESS_KEY,
+			SecretKey: cfg.S3_SECRET_KEY,
+			PathStyle: cfg.S3_PATH_STYLE,
+		})
+	} else {
+		blobs, err = blobstore.NewLocalStore(cfg.ATTACHMENT_DIR)
+	}
+	if err != nil {
+		log.Fatalf("failed to set up attachment storage: %v", err)
+	}
+
+	// Initialize due date reminders and the channels they go out on
+	reminderPolicy := services.DefaultReminderPolicy
+	reminderPolicy.Before = nil
+	for _, m := range strings.Split(cfg.REMINDER_BEFORE_MINUTES, ",") {
+		if m = strings.TrimSpace(m); m == "" {
+			continue
+		}
+		minutes, err := strconv.Atoi(m)
+		if err != nil || minutes < 1 || time.Duration(minutes)*time.Minute > services.MaxReminderOffset {
+			log.Fatalf("invalid REMINDER_BEFORE_MINUTES %q", cfg.REMINDER_BEFORE_MINUTES)
+		}
+		reminderPolicy.Before = append(reminderPolicy.Before, time.Duration(minutes)*time.Minute)
+	}
+	reminderPolicy.MaxOverdue = time.Duration(cfg.REMINDER_MAX_OVERDUE_HOURS) * time.Hour
+	reminderPolicy.InApp = false
+	if cfg.REMINDER_INTERVAL_MINUTES > 0 {
+		reminderPolicy.LockTTL = time.Duration(cfg.REMINDER_INTERVAL_MINUTES) * time.Minute
+	}
+	var reminderChannels []notifier.Notifier
+	for _, channel := range strings.Split(cfg.REMINDER_CHANNELS, ",") {
+		switch strings.TrimSpace(channel) {
+		case "inapp":
+			reminderPolicy.InApp = true
+		case "email":
+			reminderChannels = append(reminderChannels, notifier.NewEmail(mail))
+		case "webhook":
+			if cfg.REMINDER_WEBHOOK_URL == "" {
+				log.Fatalf("REMINDER_WEBHOOK_URL is required for webhook reminders")
+			}
+			reminderChannels = append(reminderChannels, notifier.NewWebhook(cfg.REMINDER_WEBHOOK_URL))
+		case "":
+		default:
+			log.Fatalf("unknown reminder channel %q", channel)
+		}
+	}
+
+	// Initialize webhook subscriptions, signed requests are retried with backoff
+	var webhookSender *webhook.Sender
+	webhookPolicy := services.DefaultWebhookPolicy
+	if cfg.WEBHOOK_INTERVAL_SECONDS > 0 {
+		allowed, err := webhook.ParseNetworks(cfg.WEBHOOK_ALLOWED_NETWORKS)
+		if err != nil {
+			log.Fatalf("invalid WEBHOOK_ALLOWED_NETWORKS %q: %v", cfg.WEBHOOK_ALLOWED_NETWORKS, err)
+		}
+		webhookSender = webhook.NewSender(time.Duration(cfg.WEBHOOK_TIMEOUT_SECONDS)*time.Second, allowed...)
+		if cfg.WEBHOOK_MAX_ATTEMPTS > 0 {
+			webhookPolicy.MaxAttempts = cfg.WEBHOOK_MAX_ATTEMPTS
+		}
+	}
+
 	// Initialize Service Layer
-	taskService := services.NewTaskService(taskRepo, redisClient, log)
+	loginPolicy := services.DefaultLoginPolicy
+	loginPolicy.MaxFailures = cfg.LOGIN_MAX_ATTEMPTS
+	loginPolicy.LockoutDuration = time.Duration(cfg.LOGIN_LOCKOUT_MINUTES) * time.Minute
+	attachmentPolicy := services.AttachmentPolicy{
+		MaxSize:      int64(cfg.ATTACHMENT_MAX_MB) << 20,
+		AllowedTypes: strings.Split(cfg.ATTACHMENT_TYPES, ","),
+		UserQuota:    int64(cfg.ATTACHMENT_QUOTA_MB) << 20,
+	}
+
+	taskService := services.NewTaskService(taskRepo, redisClient, log,
+		services.WithMailer(mail, cfg.APP_BASE_URL),
+		services.WithLoginPolicy(loginPolicy),
+		services.WithKeySet(keys),
+		services.WithSubtaskPolicy(services.SubtaskPolicy{
+			MaxDepth:              cfg.SUBTASK_MAX_DEPTH,
+			BlockParentCompletion: cfg.SUBTASK_BLOCK_PARENT_COMPLETION,
+		}),
+		services.WithTrashRetention(time.Duration(cfg.TRASH_RETENTION_DAYS)*24*time.Hour),
+		services.WithAttachments(blobs, attachmentPolicy),
+		services.WithReminders(reminderPolicy, reminderChannels...),
+		services.WithWebhooks(webhookSender, webhookPolicy),
+	)
+
+	// Empty the trash of expired tasks in the background
+	if cfg.TRASH_RETENTION_DAYS > 0 {
+		go services.RunTrashPurge(context.Background(), taskService, time.Hour, log)
+	}
+
+	// Send due date reminders in the background, replicas take turns through a redis lock
+	if cfg.REMINDER_INTERVAL_MINUTES > 0 {
+		go services.RunReminders(context.Background(), taskService, time.Duration(cfg.REMINDER_INTERVAL_MINUTES)*time.Minute, log)
+	}
+
+	// Deliver queued webhooks in the background, replicas claim separate batches
+	if cfg.WEBHOOK_INTERVAL_SECONDS > 0 {
+		go services.RunWebhookDeliveries(context.Background(), taskService, time.Duration(cfg.WEBHOOK_INTERVAL_SECONDS)*time.Second, log)
+	}
 
 	// Initialize Router
 	router := gin.Default()
 
+	handlers.NewWellKnownHandler(router, keys)
+
 	v1 := router.Group("/api/v1")
 
 	// Inject Handler Layer
-	handlers.NewTaskHandler(v1, taskService, cfg.SECERETKEY, redisClient.Client)
+	handlers.NewTaskHandler(v1, taskService, keys, redisClient.Client, attachmentPolicy.MaxSize)
 
 	// Start server
 	if err := router.Run(":" + cfg.PORT); err != nil {
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+func (h *TaskHandler) UpdateUserRole(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	var body struct {
+		Role      models.Role `json:"role"`
+		ManagerID *uint       `json:"manager_id"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || !models.IsValidRole(body.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input or role"})
+		return
+	}
+	if err := h.SVC.UpdateUserRole(actor, uint(id), body.Role, body.ManagerID); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user role updated"})
+}
+
+// writeAdminError maps service errors of the admin endpoints to responses
+func writeAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, models.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// userIDParam parses the :id path parameter, writing a 400 response when invalid
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *TaskHandler) ListUsers(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	users, total, err := h.SVC.ListUsers(actor, c.Query("search"), page, limit)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"users": users,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+func (h *TaskHandler) GetUser(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	user, err := h.SVC.GetUserDetail(actor, id)
+	if err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *TaskHandler) DisableUser(c *gin.Context) {
+	h.setUserDisabled(c, true)
+}
+
+func (h *TaskHandler) EnableUser(c *gin.Context) {
+	h.setUserDisabled(c, false)
+}
+
+func (h *TaskHandler) setUserDisabled(c *gin.Context, disabled bool) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.SVC.SetUserDisabled(actor, id, disabled); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	if disabled {
+		c.JSON(http.StatusOK, gin.H{"message": "user disabled"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user enabled"})
+}
+
+func (h *TaskHandler) ForcePasswordReset(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.SVC.ForcePasswordReset(actor, id); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "password reset required on next login"})
+}
+
+func (h *TaskHandler) UnlockUser(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.SVC.UnlockUser(actor, id); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user unlocked"})
+}
+
+// DeleteUser expects ?tasks=purge or ?tasks=reassign&reassign_to=<user id>
+func (h *TaskHandler) DeleteUser(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var reassignTo *uint
+	switch c.Query("tasks") {
+	case "purge":
+	case "reassign":
+		target, err := strconv.Atoi(c.Query("reassign_to"))
+		if err != nil || target <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reassign_to user ID"})
+			return
+		}
+		to := uint(target)
+		reassignTo = &to
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tasks must be either reassign or purge"})
+		return
+	}
+
+	if err := h.SVC.DeleteUser(actor, id, reassignTo); err != nil {
+		writeAdminError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user deleted successfully"})
+}
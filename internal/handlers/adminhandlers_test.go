@@ -0,0 +1,66 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test List Users Handler
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/admin/users", h.ListUsers)
+
+	mockService.EXPECT().ListUsers(testActor, "alice", 2, 5).
+		Return([]models.UserResponse{{ID: 3, Username: "alice@example.com"}}, int64(6), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?search=alice&page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "alice@example.com")
+	assert.NotContains(t, w.Body.String(), "\"password\"")
+}
+
+// Test Delete User Handler
+func TestDeleteUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.DELETE("/admin/users/:id", h.DeleteUser)
+
+	// The task handling has to be chosen explicitly
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/3", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.EXPECT().DeleteUser(testActor, uint(3), gomock.Any()).DoAndReturn(
+		func(_ models.Actor, _ uint, reassignTo *uint) error {
+			assert.NotNil(t, reassignTo)
+			assert.Equal(t, uint(4), *reassignTo)
+			return nil
+		})
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/3?tasks=reassign&reassign_to=4", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
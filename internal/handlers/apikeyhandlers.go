@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+func (h *TaskHandler) CreateAPIKey(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var body struct {
+		Name          string   `json:"name"`
+		Scopes        []string `json:"scopes"`
+		ExpiresInDays int      `json:"expires_in_days"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.ExpiresInDays < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	key, err := h.SVC.CreateAPIKey(userID, body.Name, body.Scopes, time.Duration(body.ExpiresInDays)*24*time.Hour)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, key)
+}
+
+func (h *TaskHandler) ListAPIKeys(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	keys, err := h.SVC.ListAPIKeys(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch api keys"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+func (h *TaskHandler) RevokeAPIKey(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key ID"})
+		return
+	}
+	if err := h.SVC.RevokeAPIKey(userID, uint(id)); err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "api key not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke api key"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "api key revoked"})
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+func (h *TaskHandler) UpdateAssignees(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
+	var body struct {
+		Assign   []uint `json:"assign"`
+		Unassign []uint `json:"unassign"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || len(body.Assign)+len(body.Unassign) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	task, err := h.SVC.UpdateAssignees(actor, uint(id), body.Assign, body.Unassign)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrTaskNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		case errors.Is(err, models.ErrUserNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "assignee not found"})
+		case errors.Is(err, services.ErrAssigneeDisabled):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrAssigneeCannotSeeTask):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update assignees"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,48 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Update Assignees Handler
+func TestUpdateAssignees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PATCH("/tasks/:id/assignees", h.UpdateAssignees)
+
+	mockService.EXPECT().UpdateAssignees(testActor, uint(1), []uint{3}, nil).Return(&models.Task{ID: 1, AssigneeIDs: []uint{3}}, nil)
+	mockService.EXPECT().UpdateAssignees(testActor, uint(2), []uint{3}, nil).Return(nil, services.ErrAssigneeDisabled)
+	mockService.EXPECT().UpdateAssignees(testActor, uint(3), []uint{3}, nil).Return(nil, errors.New("pq: connection refused"))
+
+	for _, tc := range []struct {
+		url  string
+		code int
+	}{
+		{"/api/v1/tasks/1/assignees", http.StatusOK},
+		{"/api/v1/tasks/2/assignees", http.StatusBadRequest},
+		{"/api/v1/tasks/3/assignees", http.StatusInternalServerError},
+	} {
+		req := httptest.NewRequest(http.MethodPatch, tc.url, strings.NewReader(`{"assign": [3]}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tc.code, w.Code, tc.url)
+		assert.NotContains(t, w.Body.String(), "pq:")
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// multipartOverhead is the room left in upload bodies for the multipart framing
+// around the file
+const multipartOverhead = 1 << 20
+
+// writeAttachmentError writes the response for errors of the attachment endpoints
+// and reports whether err was one of them
+func writeAttachmentError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, models.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+	case errors.Is(err, models.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentSize), errors.Is(err, models.ErrStorageQuotaExceeded):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAttachmentsDisabled):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *TaskHandler) UploadAttachment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	// The form is spooled to disk while it is parsed, cap it before the service
+	// gets to check the size
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.MaxUpload+multipartOverhead)
+	header, err := c.FormFile("file")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": services.ErrAttachmentSize.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a file is required in the file form field"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read upload"})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.SVC.UploadAttachment(actor, id, header.Filename, header.Size, file)
+	if err != nil {
+		if !writeAttachmentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store attachment"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, attachment)
+}
+
+func (h *TaskHandler) ListAttachments(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	attachments, err := h.SVC.ListAttachments(actor, id)
+	if err != nil {
+		if !writeAttachmentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachments"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+func (h *TaskHandler) DownloadAttachment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	attachmentID, ok := uintParam(c, "attachmentId", "invalid attachment ID")
+	if !ok {
+		return
+	}
+	attachment, body, err := h.SVC.OpenAttachment(actor, id, attachmentID)
+	if err != nil {
+		if !writeAttachmentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachment"})
+		}
+		return
+	}
+	defer body.Close()
+
+	// Always download, browsers must not render uploaded content inline
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *TaskHandler) DeleteAttachment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	attachmentID, ok := uintParam(c, "attachmentId", "invalid attachment ID")
+	if !ok {
+		return
+	}
+	if err := h.SVC.DeleteAttachment(actor, id, attachmentID); err != nil {
+		if !writeAttachmentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete attachment"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted"})
+}
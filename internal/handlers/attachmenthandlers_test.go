@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func uploadRequest(t *testing.T, fileName, content string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", fileName)
+	assert.NoError(t, err)
+	_, _ = io.WriteString(part, content)
+	assert.NoError(t, form.Close())
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/attachments", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+// Test Upload Attachment Handler
+func TestUploadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService, MaxUpload: 10}
+	apiGroup.POST("/tasks/:id/attachments", h.UploadAttachment)
+
+	mockService.EXPECT().UploadAttachment(testActor, uint(1), "notes.txt", int64(5), gomock.Any()).
+		Return(&models.Attachment{ID: 4, TaskID: 1, FileName: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 5}, nil)
+	mockService.EXPECT().UploadAttachment(testActor, uint(1), "tool.exe", int64(2), gomock.Any()).
+		Return(nil, services.ErrAttachmentType)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "notes.txt", "hello"))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"file_name":"notes.txt"`)
+	assert.NotContains(t, w.Body.String(), "storage")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "tool.exe", "MZ"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/attachments", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Bodies past the limit are cut off before the form is parsed
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, uploadRequest(t, "big.txt", strings.Repeat("a", 2<<20)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+// Test Download Attachment Handler
+func TestDownloadAttachment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/attachments/:attachmentId", h.DownloadAttachment)
+
+	mockService.EXPECT().OpenAttachment(testActor, uint(1), uint(4)).Return(
+		&models.Attachment{ID: 4, TaskID: 1, FileName: "notes.txt", ContentType: "text/plain; charset=utf-8", Size: 5},
+		io.NopCloser(strings.NewReader("hello")), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/attachments/4", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", w.Body.String())
+	assert.Equal(t, `attachment; filename=notes.txt`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
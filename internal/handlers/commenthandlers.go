@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeCommentError writes the response for errors of the comment endpoints and
+// reports whether err was one of them
+func writeCommentError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, models.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "comment not found"})
+	case errors.Is(err, models.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// commentBody binds the Markdown body of a comment request
+func commentBody(c *gin.Context) (string, bool) {
+	var body struct {
+		Body string `json:"body"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return "", false
+	}
+	return body.Body, true
+}
+
+func (h *TaskHandler) AddComment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	body, ok := commentBody(c)
+	if !ok {
+		return
+	}
+	comment, err := h.SVC.AddComment(actor, id, body)
+	if err != nil {
+		if !writeCommentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add comment"})
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+func (h *TaskHandler) ListComments(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	page, limit := pageQuery(c)
+
+	comments, total, err := h.SVC.ListComments(actor, id, page, limit)
+	if err != nil {
+		if !writeCommentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch comments"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"comments": comments,
+		"page":     page,
+		"limit":    limit,
+		"total":    total,
+	})
+}
+
+func (h *TaskHandler) UpdateComment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	commentID, ok := uintParam(c, "commentId", "invalid comment ID")
+	if !ok {
+		return
+	}
+	body, ok := commentBody(c)
+	if !ok {
+		return
+	}
+	comment, err := h.SVC.UpdateComment(actor, id, commentID, body)
+	if err != nil {
+		if !writeCommentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update comment"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+func (h *TaskHandler) DeleteComment(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	commentID, ok := uintParam(c, "commentId", "invalid comment ID")
+	if !ok {
+		return
+	}
+	if err := h.SVC.DeleteComment(actor, id, commentID); err != nil {
+		if !writeCommentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete comment"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "comment deleted"})
+}
+
+func (h *TaskHandler) GetCommentEdits(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	commentID, ok := uintParam(c, "commentId", "invalid comment ID")
+	if !ok {
+		return
+	}
+	edits, err := h.SVC.GetCommentEdits(actor, id, commentID)
+	if err != nil {
+		if !writeCommentError(c, err) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch comment edits"})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"edits": edits})
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Add Comment Handler
+func TestAddComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/tasks/:id/comments", h.AddComment)
+
+	mockService.EXPECT().AddComment(testActor, uint(1), "Looks *good*").
+		Return(&models.Comment{ID: 3, TaskID: 1, AuthorID: 1, Body: "Looks *good*"}, nil)
+	mockService.EXPECT().AddComment(testActor, uint(1), "").Return(nil, services.ErrInvalidComment)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/comments", bytes.NewBufferString(`{"body": "Looks *good*"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"body":"Looks *good*"`)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/comments", bytes.NewBufferString(`{"body": ""}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Test Update Comment Handler
+func TestUpdateComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/tasks/:id/comments/:commentId", h.UpdateComment)
+
+	mockService.EXPECT().UpdateComment(testActor, uint(1), uint(3), "edited").Return(nil, models.ErrForbidden)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1/comments/3", bytes.NewBufferString(`{"body": "edited"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// Test List Comments Handler
+func TestListComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/comments", h.ListComments)
+
+	mockService.EXPECT().ListComments(testActor, uint(1), 1, 20).
+		Return([]models.Comment{{ID: 3, TaskID: 1, AuthorID: 1, Body: "hi"}}, int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/comments?limit=20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeDependencyError writes the response for dependency errors of the task
+// endpoints and reports whether err was one
+func writeDependencyError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrTaskBlocked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrDependencyCycle):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, models.ErrDependencyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *TaskHandler) AddDependency(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	var body struct {
+		DependsOn uint `json:"depends_on"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.DependsOn == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	task, err := h.SVC.AddDependency(actor, id, body.DependsOn)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if writeDependencyError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add dependency"})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) RemoveDependency(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	dependsOnID, ok := uintParam(c, "dependsOnId", "invalid task ID")
+	if !ok {
+		return
+	}
+	task, err := h.SVC.RemoveDependency(actor, id, dependsOnID)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if writeDependencyError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove dependency"})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Add Dependency Handler
+func TestAddDependency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/tasks/:id/dependencies", h.AddDependency)
+
+	mockService.EXPECT().AddDependency(testActor, uint(2), uint(1)).
+		Return(&models.Task{ID: 2, DependsOn: []uint{1}, Blocked: true}, nil)
+	mockService.EXPECT().AddDependency(testActor, uint(1), uint(2)).Return(nil, models.ErrDependencyCycle)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/2/dependencies", bytes.NewBufferString(`{"depends_on": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"blocked":true`)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/1/dependencies", bytes.NewBufferString(`{"depends_on": 2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+// Test Update Task Handler starting a blocked task
+func TestUpdateTask_Force(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/tasks/:id", h.UpdateTask)
+
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(services.ErrTaskBlocked)
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), true).Return(nil)
+
+	for path, code := range map[string]int{"/api/v1/tasks/2": http.StatusConflict, "/api/v1/tasks/2?force=true": http.StatusOK} {
+		body := bytes.NewBufferString(`{"title": "Deploy", "status": "In Progress"}`)
+		req := httptest.NewRequest(http.MethodPut, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code, path)
+	}
+}
+
+// Test Get All Tasks Handler with the blocked filter
+func TestGetAllTasks_BlockedFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks", h.GetAllTasks)
+
+	blocked := true
+	mockService.EXPECT().GetAllTasks(testActor, models.TaskFilter{
+		Blocked: &blocked, SortBy: "due_date", SortOrder: "asc", Page: 1, Limit: 10,
+	}).Return([]models.Task{{ID: 2, DependsOn: []uint{1}, Blocked: true}}, int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?blocked=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"DependsOn":[1],"Blocked":true`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?blocked=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
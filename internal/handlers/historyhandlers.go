@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// pageQuery reads the page and limit query parameters, 50 entries a page by default
+// and at most 100
+func pageQuery(c *gin.Context) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return page, limit
+}
+
+func (h *TaskHandler) GetTaskHistory(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	page, limit := pageQuery(c)
+
+	history, total, err := h.SVC.GetTaskHistory(actor, id, page, limit)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch history"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"history": history,
+		"page":    page,
+		"limit":   limit,
+		"total":   total,
+	})
+}
@@ -0,0 +1,43 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Get Task History Handler
+func TestGetTaskHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/history", h.GetTaskHistory)
+
+	mockService.EXPECT().GetTaskHistory(testActor, uint(1), 2, 50).Return([]models.TaskHistory{{
+		ID: 3, TaskID: 1, ActorID: 1, Action: models.HistoryUpdated,
+		Changes: []models.FieldChange{{Field: "title", Before: "Draft", After: "Final"}},
+	}}, int64(51), nil)
+	mockService.EXPECT().GetTaskHistory(testActor, uint(2), 1, 50).Return(nil, int64(0), models.ErrTaskNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/history?page=2&limit=500", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"changes":[{"field":"title","before":"Draft","after":"Final"}]`)
+	assert.Contains(t, w.Body.String(), `"total":51`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/2/history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeLabelError maps service errors of the label endpoints to responses
+func writeLabelError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrLabelNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "label not found"})
+	case errors.Is(err, models.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+	case errors.Is(err, services.ErrLabelExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// uintParam parses a positive numeric path parameter, writing a 400 response when invalid
+func uintParam(c *gin.Context, name, message string) (uint, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *TaskHandler) CreateLabel(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var label models.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := h.SVC.CreateLabel(actor, &label); err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, label)
+}
+
+func (h *TaskHandler) ListLabels(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	labels, err := h.SVC.ListLabels(actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch labels"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"labels": labels})
+}
+
+func (h *TaskHandler) UpdateLabel(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid label ID")
+	if !ok {
+		return
+	}
+	var label models.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	label.ID = id
+	if err := h.SVC.UpdateLabel(actor, &label); err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, label)
+}
+
+func (h *TaskHandler) DeleteLabel(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid label ID")
+	if !ok {
+		return
+	}
+	if err := h.SVC.DeleteLabel(actor, id); err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "label deleted successfully"})
+}
+
+func (h *TaskHandler) AttachLabels(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	var body struct {
+		LabelIDs []uint `json:"label_ids"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || len(body.LabelIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	task, err := h.SVC.AttachLabels(actor, id, body.LabelIDs)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) DetachLabel(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	labelID, ok := uintParam(c, "labelId", "invalid label ID")
+	if !ok {
+		return
+	}
+	task, err := h.SVC.DetachLabel(actor, id, labelID)
+	if err != nil {
+		writeLabelError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// LoginMFA is the second login step for users with 2FA enabled
+func (h *TaskHandler) LoginMFA(c *gin.Context) {
+	var body struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.MFAToken == "" || body.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	tokens, err := h.SVC.CompleteMFALogin(body.MFAToken, body.Code, c.ClientIP())
+	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Round(time.Second).Seconds())))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *TaskHandler) EnableTOTP(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	enrollment, err := h.SVC.BeginTOTPEnrollment(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, enrollment)
+}
+
+func (h *TaskHandler) VerifyTOTP(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	codes, err := h.SVC.ConfirmTOTPEnrollment(userID, body.Code)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "two-factor authentication enabled",
+		"recovery_codes": codes,
+	})
+}
+
+func (h *TaskHandler) DisableTOTP(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	var body struct {
+		Password string `json:"password"`
+		Code     string `json:"code"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.Password == "" || body.Code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := h.SVC.DisableTOTP(userID, body.Password, body.Code); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "two-factor authentication disabled"})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+func (h *TaskHandler) ListNotifications(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	page, limit := pageQuery(c)
+	unreadOnly := c.Query("unread") == "true"
+
+	notifications, total, unread, err := h.SVC.ListNotifications(actor, unreadOnly, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notifications"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"unread":        unread,
+		"page":          page,
+		"limit":         limit,
+		"total":         total,
+	})
+}
+
+func (h *TaskHandler) MarkNotificationRead(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid notification ID")
+	if !ok {
+		return
+	}
+
+	if err := h.SVC.MarkNotificationRead(actor, id); err != nil {
+		if errors.Is(err, models.ErrNotificationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark notification as read"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "notification marked as read"})
+}
+
+func (h *TaskHandler) MarkAllNotificationsRead(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	marked, err := h.SVC.MarkAllNotificationsRead(actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark notifications as read"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"marked": marked})
+}
+
+func (h *TaskHandler) GetNotificationPreferences(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.SVC.GetNotificationPreferences(actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch notification preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, prefs)
+}
+
+func (h *TaskHandler) UpdateNotificationPreferences(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var prefs map[string]bool
+	if err := c.ShouldBindJSON(&prefs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	updated, err := h.SVC.UpdateNotificationPreferences(actor, prefs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
@@ -0,0 +1,88 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test List Notifications Handler
+func TestListNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/notifications", h.ListNotifications)
+
+	mockService.EXPECT().ListNotifications(testActor, true, 1, 50).Return([]models.Notification{
+		{ID: 3, Type: models.NotificationMention, TaskID: 7, Message: "you were mentioned"},
+	}, int64(1), int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/notifications?unread=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"unread":1`)
+	assert.Contains(t, w.Body.String(), `"type":"mention"`)
+}
+
+// Test Mark Notification Read Handler
+func TestMarkNotificationRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/notifications/:id/read", h.MarkNotificationRead)
+
+	mockService.EXPECT().MarkNotificationRead(testActor, uint(3)).Return(nil)
+	mockService.EXPECT().MarkNotificationRead(testActor, uint(4)).Return(models.ErrNotificationNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/notifications/3/read", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/notifications/4/read", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test Update Notification Preferences Handler
+func TestUpdateNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/notifications/preferences", h.UpdateNotificationPreferences)
+
+	prefs := map[string]bool{models.NotificationStatus: false}
+	mockService.EXPECT().UpdateNotificationPreferences(testActor, prefs).Return(map[string]bool{
+		models.NotificationMention:    true,
+		models.NotificationAssignment: true,
+		models.NotificationStatus:     false,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/notifications/preferences", strings.NewReader(`{"status":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":false`)
+}
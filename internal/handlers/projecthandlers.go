@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeProjectError maps service errors of the project endpoints to responses
+func writeProjectError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+	case errors.Is(err, models.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, models.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// writeProjectTaskError writes the response for project errors of the task
+// endpoints and reports whether err was one
+func writeProjectTaskError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, models.ErrProjectNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "project not found"})
+	case errors.Is(err, services.ErrProjectArchived):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+// projectIDParam parses the :id path parameter, writing a 400 response when invalid
+func projectIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *TaskHandler) CreateProject(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var project models.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := h.SVC.CreateProject(actor, &project); err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, project)
+}
+
+func (h *TaskHandler) ListProjects(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	includeArchived := c.Query("archived") == "true"
+	projects, err := h.SVC.ListProjects(actor, includeArchived)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch projects"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+func (h *TaskHandler) GetProject(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	project, err := h.SVC.GetProject(actor, id)
+	if err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *TaskHandler) UpdateProject(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	var project models.Project
+	if err := c.ShouldBindJSON(&project); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	project.ID = id
+	if err := h.SVC.UpdateProject(actor, &project); err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+func (h *TaskHandler) DeleteProject(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	if err := h.SVC.DeleteProject(actor, id); err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "project deleted successfully"})
+}
+
+func (h *TaskHandler) AddProjectMember(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	var body struct {
+		UserID uint `json:"user_id"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.UserID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := h.SVC.AddProjectMember(actor, id, body.UserID); err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "member added"})
+}
+
+func (h *TaskHandler) RemoveProjectMember(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userId"))
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+	if err := h.SVC.RemoveProjectMember(actor, id, uint(userID)); err != nil {
+		writeProjectError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "member removed"})
+}
+
+func (h *TaskHandler) GetProjectTasks(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+	filter, ok := taskFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	tasks, total, err := h.SVC.GetProjectTasks(actor, id, filter)
+	if err != nil {
+		if errors.Is(err, models.ErrProjectNotFound) {
+			writeProjectError(c, err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+	writeTaskList(c, tasks, total, filter)
+}
@@ -0,0 +1,68 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Get Project Tasks Handler
+func TestGetProjectTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/projects/:id/tasks", h.GetProjectTasks)
+
+	mockService.EXPECT().GetProjectTasks(testActor, uint(4), models.TaskFilter{
+		Status: "Pending", SortBy: "due_date", SortOrder: "asc", Page: 1, Limit: 10,
+	}).Return([]models.Task{{ID: 7, Title: "Client review"}}, int64(1), nil)
+	mockService.EXPECT().GetProjectTasks(testActor, uint(5), gomock.Any()).
+		Return(nil, int64(0), models.ErrProjectNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/4/tasks?status=Pending", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Client review")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/projects/5/tasks", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test Create Task Handler on an archived project
+func TestCreateTask_ArchivedProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/tasks", h.CreateTask)
+
+	mockService.EXPECT().CreateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, uint(4), *task.ProjectID)
+		return services.ErrProjectArchived
+	})
+
+	body := `{"title":"Kickoff","status":"Pending","projectId":4}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
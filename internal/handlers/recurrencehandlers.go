@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// maxPreviewCount is the most occurrences a recurrence preview lists
+const maxPreviewCount = 50
+
+func (h *TaskHandler) PreviewRecurrence(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	count, err := strconv.Atoi(c.DefaultQuery("count", "5"))
+	if err != nil || count < 1 || count > maxPreviewCount {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "count must be 1-50"})
+		return
+	}
+
+	occurrences, err := h.SVC.PreviewRecurrence(actor, id, count)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if writeScheduleError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to preview recurrence"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"occurrences": occurrences})
+}
+
+func (h *TaskHandler) StopRecurrence(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+
+	task, err := h.SVC.StopRecurrence(actor, id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if writeScheduleError(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to stop recurrence"})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
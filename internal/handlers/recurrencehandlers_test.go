@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Preview Recurrence Handler
+func TestPreviewRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/recurrence", h.PreviewRecurrence)
+
+	mockService.EXPECT().PreviewRecurrence(testActor, uint(7), 2).Return([]time.Time{
+		time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC),
+	}, nil)
+	mockService.EXPECT().PreviewRecurrence(testActor, uint(8), 5).Return(nil, services.ErrNotRecurring)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/7/recurrence?count=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"2024-05-10T09:00:00Z"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/8/recurrence", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/7/recurrence?count=500", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Test Stop Recurrence Handler
+func TestStopRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.DELETE("/tasks/:id/recurrence", h.StopRecurrence)
+
+	mockService.EXPECT().StopRecurrence(testActor, uint(7)).Return(&models.Task{ID: 7, Title: "Backups"}, nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/7/recurrence", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"recurrence"`)
+}
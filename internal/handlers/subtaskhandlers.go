@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeSubtaskError writes the response for parent and subtask errors of the task
+// endpoints and reports whether err was one
+func writeSubtaskError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrParentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrParentScope),
+		errors.Is(err, services.ErrSubtaskCycle),
+		errors.Is(err, services.ErrSubtaskDepth):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOpenSubtasks),
+		errors.Is(err, services.ErrSubtasksInProject):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *TaskHandler) GetSubtasks(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	subtasks, progress, err := h.SVC.GetSubtasks(actor, id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch subtasks"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subtasks": subtasks, "progress": progress})
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Get Subtasks Handler
+func TestGetSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/subtasks", h.GetSubtasks)
+
+	parentID := uint(1)
+	mockService.EXPECT().GetSubtasks(testActor, uint(1)).Return(
+		[]models.Task{{ID: 2, Title: "Write tests", ParentID: &parentID}},
+		&models.TaskProgress{Total: 4, Completed: 1, Percent: 25}, nil)
+	mockService.EXPECT().GetSubtasks(testActor, uint(9)).Return(nil, nil, models.ErrTaskNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/subtasks", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"parentId":1`)
+	assert.Contains(t, w.Body.String(), `"percent":25`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks/9/subtasks", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test Update Task Handler with subtask rule violations
+func TestUpdateTask_SubtaskErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/tasks/:id", h.UpdateTask)
+
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(fmt.Errorf("%w: 1 of 2 completed", services.ErrOpenSubtasks))
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(services.ErrSubtaskCycle)
+
+	for _, code := range []int{http.StatusConflict, http.StatusUnprocessableEntity} {
+		body := []byte(`{"title": "Epic", "status": "Completed", "parentId": 3}`)
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, code, w.Code)
+	}
+}
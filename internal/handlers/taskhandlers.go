@@ -1,42 +1,218 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	redis "github.com/go-redis/redis/v8"
 	"github.com/ratheeshkumar25/task-mgt/internal/middleware"
 	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/recurrence"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
 	inter "github.com/ratheeshkumar25/task-mgt/internal/services/interfaces"
+	"github.com/ratheeshkumar25/task-mgt/utility"
 )
 
 type TaskHandler struct {
-	SVC       inter.TaskServiceInter
-	JWTSecret string
+	SVC  inter.TaskServiceInter
+	Keys *utility.KeySet
+	// MaxUpload is the largest attachment accepted, request bodies are capped
+	// with it before the upload is parsed
+	MaxUpload int64
 }
 
-func NewTaskHandler(router *gin.RouterGroup, svc inter.TaskServiceInter, secret string, redisClient *redis.Client) {
-	h := &TaskHandler{SVC: svc, JWTSecret: secret}
+func NewTaskHandler(router *gin.RouterGroup, svc inter.TaskServiceInter, keys *utility.KeySet, redisClient *redis.Client, maxUpload int64) {
+	h := &TaskHandler{SVC: svc, Keys: keys, MaxUpload: maxUpload}
+	tokenStore := utility.NewTokenStore(redisClient)
 
-	// Public routes
-	router.POST("/login", h.Login)
-	router.POST("/register", h.Register)
+	// Public routes, rate limited per client IP
+	public := router.Group("")
+	public.Use(middleware.RateLimitMiddleware(redisClient, 20, time.Minute))
+	{
+		public.POST("/login", h.Login)
+		public.POST("/login/mfa", h.LoginMFA)
+		public.POST("/register", h.Register)
+		public.POST("/token/refresh", h.RefreshToken)
+		public.GET("/verify-email", h.VerifyEmail)
+		public.POST("/verify-email", h.VerifyEmail)
+		public.POST("/password/forgot", h.ForgotPassword)
+		public.POST("/password/reset", h.ResetPassword)
+	}
 
-	// Protected task routes
+	// Protected session routes
+	session := router.Group("")
+	session.Use(middleware.AuthMiddleware(keys, tokenStore, svc, nil))
+	{
+		session.POST("/logout", h.Logout)
+		session.POST("/logout-all", h.LogoutAll)
+		session.POST("/verify-email/resend", h.ResendVerificationEmail)
+		session.POST("/2fa/enable", h.EnableTOTP)
+		session.POST("/2fa/verify", h.VerifyTOTP)
+		session.POST("/2fa/disable", h.DisableTOTP)
+		session.POST("/api-keys", h.CreateAPIKey)
+		session.GET("/api-keys", h.ListAPIKeys)
+		session.DELETE("/api-keys/:id", h.RevokeAPIKey)
+	}
+
+	// Protected task routes, also reachable with an API key
 	auth := router.Group("/tasks")
 	auth.Use(
-		middleware.AuthMiddleware(secret),
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
 		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
 	)
 	{
 		auth.POST("", h.CreateTask)
 		auth.GET("", h.GetAllTasks)
+		auth.GET("/trash", h.GetTrash)
+		auth.DELETE("/trash", h.EmptyTrash)
+		auth.DELETE("/trash/:id", h.PurgeTask)
 		auth.GET("/:id", h.GetTaskByID)
 		auth.PUT("/:id", h.UpdateTask)
 		auth.DELETE("/:id", h.DeleteTask)
+		auth.POST("/:id/restore", h.RestoreTask)
+		auth.PATCH("/:id/assignees", h.UpdateAssignees)
+		auth.GET("/:id/subtasks", h.GetSubtasks)
+		auth.GET("/:id/transitions", h.GetTaskTransitions)
+		auth.GET("/:id/history", h.GetTaskHistory)
+		auth.GET("/:id/recurrence", h.PreviewRecurrence)
+		auth.DELETE("/:id/recurrence", h.StopRecurrence)
+		auth.POST("/:id/dependencies", h.AddDependency)
+		auth.DELETE("/:id/dependencies/:dependsOnId", h.RemoveDependency)
+		auth.POST("/:id/labels", h.AttachLabels)
+		auth.DELETE("/:id/labels/:labelId", h.DetachLabel)
+		auth.GET("/:id/attachments", h.ListAttachments)
+		auth.POST("/:id/attachments", h.UploadAttachment)
+		auth.GET("/:id/attachments/:attachmentId", h.DownloadAttachment)
+		auth.DELETE("/:id/attachments/:attachmentId", h.DeleteAttachment)
+		auth.GET("/:id/comments", h.ListComments)
+		auth.POST("/:id/comments", h.AddComment)
+		auth.PUT("/:id/comments/:commentId", h.UpdateComment)
+		auth.DELETE("/:id/comments/:commentId", h.DeleteComment)
+		auth.GET("/:id/comments/:commentId/edits", h.GetCommentEdits)
+	}
+
+	// Label routes, same access as the task routes
+	labels := router.Group("/labels")
+	labels.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
+	)
+	{
+		labels.POST("", h.CreateLabel)
+		labels.GET("", h.ListLabels)
+		labels.PUT("/:id", h.UpdateLabel)
+		labels.DELETE("/:id", h.DeleteLabel)
+	}
+
+	// Project routes, same access as the task routes
+	projects := router.Group("/projects")
+	projects.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
+	)
+	{
+		projects.POST("", h.CreateProject)
+		projects.GET("", h.ListProjects)
+		projects.GET("/:id", h.GetProject)
+		projects.PUT("/:id", h.UpdateProject)
+		projects.DELETE("/:id", h.DeleteProject)
+		projects.POST("/:id/members", h.AddProjectMember)
+		projects.DELETE("/:id/members/:userId", h.RemoveProjectMember)
+		projects.GET("/:id/tasks", h.GetProjectTasks)
+	}
+
+	// Workflow, readable with the same access as the task routes
+	workflow := router.Group("/workflow")
+	workflow.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
+	)
+	{
+		workflow.GET("", h.GetWorkflow)
+	}
+
+	// Notifications of the calling user
+	notifications := router.Group("/notifications")
+	notifications.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
+	)
+	{
+		notifications.GET("", h.ListNotifications)
+		notifications.POST("/read-all", h.MarkAllNotificationsRead)
+		notifications.POST("/:id/read", h.MarkNotificationRead)
+		notifications.GET("/preferences", h.GetNotificationPreferences)
+		notifications.PUT("/preferences", h.UpdateNotificationPreferences)
+	}
+
+	// Webhook subscriptions of the calling user
+	webhooks := router.Group("/webhooks")
+	webhooks.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, svc),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+		middleware.RateLimitMiddleware(redisClient, 60, time.Minute),
+	)
+	{
+		webhooks.POST("", h.CreateWebhook)
+		webhooks.GET("", h.ListWebhooks)
+		webhooks.GET("/:id", h.GetWebhook)
+		webhooks.PUT("/:id", h.UpdateWebhook)
+		webhooks.DELETE("/:id", h.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", h.ListWebhookDeliveries)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.RedeliverWebhook)
+	}
+
+	// Admin routes
+	admin := router.Group("/admin")
+	admin.Use(
+		middleware.AuthMiddleware(keys, tokenStore, svc, nil),
+		middleware.RequireRole(string(models.RoleAdmin)),
+	)
+	{
+		admin.GET("/users", h.ListUsers)
+		admin.GET("/users/:id", h.GetUser)
+		admin.PUT("/users/:id/role", h.UpdateUserRole)
+		admin.POST("/users/:id/disable", h.DisableUser)
+		admin.POST("/users/:id/enable", h.EnableUser)
+		admin.POST("/users/:id/force-password-reset", h.ForcePasswordReset)
+		admin.POST("/users/:id/unlock", h.UnlockUser)
+		admin.DELETE("/users/:id", h.DeleteUser)
+		admin.PUT("/workflow", h.UpdateWorkflow)
+	}
+}
+
+// currentActor returns the authenticated user and role set by middleware.AuthMiddleware,
+// writing a 401 response when it is missing.
+func currentActor(c *gin.Context) (models.Actor, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return models.Actor{}, false
+	}
+	role := models.Role(c.GetString("role"))
+	if role == "" {
+		role = models.RoleUser
+	}
+	return models.Actor{UserID: userID, Role: role}, true
+}
+
+// currentUserID returns the authenticated user set by middleware.AuthMiddleware,
+// writing a 401 response when it is missing.
+func currentUserID(c *gin.Context) (uint, bool) {
+	userID := c.GetUint("userID")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthenticated"})
+		return 0, false
 	}
+	return userID, true
 }
 
 func (h *TaskHandler) Register(c *gin.Context) {
@@ -61,23 +237,99 @@ func (h *TaskHandler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
 		return
 	}
-	token, err := h.SVC.LoginUser(loginData.Username, loginData.Password)
+	tokens, err := h.SVC.LoginUser(loginData.Username, loginData.Password, c.ClientIP())
 	if err != nil {
+		var throttled *services.LoginThrottledError
+		if errors.As(err, &throttled) {
+			c.Header("Retry-After", strconv.Itoa(int(throttled.RetryAfter.Round(time.Second).Seconds())))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *TaskHandler) RefreshToken(c *gin.Context) {
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	tokens, err := h.SVC.RefreshToken(body.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *TaskHandler) Logout(c *gin.Context) {
+	value, exists := c.Get("claims")
+	claims, ok := value.(*utility.UserClaim)
+	if !exists || !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthenticated"})
+		return
+	}
+	if err := h.SVC.Logout(claims); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
+}
+
+func (h *TaskHandler) LogoutAll(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.SVC.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to logout"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out from all sessions"})
+}
+
+// writeScheduleError writes the response for invalid due date reminders and
+// recurrence rules, and reports whether err was one
+func writeScheduleError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrInvalidReminder),
+		errors.Is(err, recurrence.ErrInvalidRule),
+		errors.Is(err, services.ErrRecurrenceNeedsDueDate),
+		errors.Is(err, services.ErrNotRecurring):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
 }
 
 func (h *TaskHandler) CreateTask(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 	var task models.Task
-	if err := c.ShouldBindJSON(&task); err != nil || task.Title == "" || !models.IsValidStatus(task.Status) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input or status"})
+	// The status is checked against the workflow by the service
+	if err := c.ShouldBindJSON(&task); err != nil || task.Title == "" ||
+		(task.Priority != "" && !models.IsValidPriority(task.Priority)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input or priority"})
 		return
 	}
+	task.ID = 0
+	task.UserID = userID
+	task.Progress, task.DependsOn, task.Blocked = nil, nil, false
 	task.CreatedAt = time.Now()
 	task.UpdatedAt = time.Now()
 	if err := h.SVC.CreateTask(&task); err != nil {
+		if writeProjectTaskError(c, err) || writeSubtaskError(c, err) || writeWorkflowError(c, err) ||
+			writeScheduleError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create task"})
 		return
 	}
@@ -85,6 +337,27 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 }
 
 func (h *TaskHandler) GetAllTasks(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	filter, ok := taskFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	// Call service
+	tasks, total, err := h.SVC.GetAllTasks(actor, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
+		return
+	}
+	writeTaskList(c, tasks, total, filter)
+}
+
+// taskFilterFromQuery reads the list query parameters shared by the task list
+// endpoints, writing a 400 response when one is invalid
+func taskFilterFromQuery(c *gin.Context) (models.TaskFilter, bool) {
 	// Read query params
 	status := c.Query("status")
 	dueDateAfter := c.Query("due_date_after")
@@ -102,58 +375,182 @@ func (h *TaskHandler) GetAllTasks(c *gin.Context) {
 		limit = 10
 	}
 
-	// Call service
-	tasks, total, err := h.SVC.GetAllTasks(status, dueDateAfter, sortBy, sortOrder, page, limit)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch tasks"})
-		return
+	// Sort fields go into ORDER BY, so only known ones are accepted
+	if !models.IsValidSortField(sortBy) || !models.IsValidSortOrder(sortOrder) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort_by or sort_order"})
+		return models.TaskFilter{}, false
+	}
+
+	// priority=high,urgent
+	var priorities []models.TaskPriority
+	if raw := c.Query("priority"); raw != "" {
+		for _, p := range strings.Split(raw, ",") {
+			priority := models.TaskPriority(strings.TrimSpace(p))
+			if !models.IsValidPriority(priority) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid priority"})
+				return models.TaskFilter{}, false
+			}
+			priorities = append(priorities, priority)
+		}
+	}
+
+	// assignee=me or assignee=<user id>
+	var assigneeID uint
+	switch assignee := c.Query("assignee"); assignee {
+	case "":
+	case "me":
+		assigneeID = c.GetUint("userID")
+	default:
+		id, err := strconv.Atoi(assignee)
+		if err != nil || id <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid assignee"})
+			return models.TaskFilter{}, false
+		}
+		assigneeID = uint(id)
 	}
 
+	// labels=bug,frontend&label_mode=any|all
+	var labels []string
+	if raw := c.Query("labels"); raw != "" {
+		for _, l := range strings.Split(raw, ",") {
+			if name := strings.TrimSpace(l); name != "" {
+				labels = append(labels, name)
+			}
+		}
+	}
+	labelMode := c.Query("label_mode")
+	if labelMode != "" && !models.IsValidLabelMode(labelMode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid label_mode"})
+		return models.TaskFilter{}, false
+	}
+	if len(labels) > 0 && labelMode == "" {
+		labelMode = models.LabelModeAny
+	}
+
+	// blocked=true|false
+	var blocked *bool
+	if raw := c.Query("blocked"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid blocked"})
+			return models.TaskFilter{}, false
+		}
+		blocked = &value
+	}
+
+	return models.TaskFilter{
+		Status:       status,
+		Priorities:   priorities,
+		Labels:       labels,
+		LabelMode:    labelMode,
+		Blocked:      blocked,
+		DueDateAfter: dueDateAfter,
+		AssigneeID:   assigneeID,
+		SortBy:       sortBy,
+		SortOrder:    sortOrder,
+		Page:         page,
+		Limit:        limit,
+	}, true
+}
+
+// writeTaskList writes a page of tasks as the list response DTO
+func writeTaskList(c *gin.Context, tasks []models.Task, total int64, filter models.TaskFilter) {
 	// Map tasks to response DTO
 	var taskResponses []models.TaskResponse
 	for _, task := range tasks {
-		taskResponses = append(taskResponses, models.TaskResponse{
-			ID:        task.ID,
-			Title:     task.Title,
-			Status:    string(task.Status),
-			CreatedAt: task.CreatedAt.UTC().Format(time.RFC3339),
-			UpdatedAt: task.UpdatedAt.UTC().Format(time.RFC3339),
-		})
+		response := models.TaskResponse{
+			ID:           task.ID,
+			Title:        task.Title,
+			Status:       string(task.Status),
+			Priority:     string(task.Priority),
+			ProjectID:    task.ProjectID,
+			AssigneeIDs:  task.AssigneeIDs,
+			Labels:       task.Labels,
+			ParentID:     task.ParentID,
+			Progress:     task.Progress,
+			DependsOn:    task.DependsOn,
+			Blocked:      task.Blocked,
+			CommentCount: task.CommentCount,
+			CreatedAt:    task.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:    task.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if task.DueDate != nil {
+			dueDate := task.DueDate.UTC().Format(time.RFC3339)
+			response.DueDate = &dueDate
+		}
+		if response.AssigneeIDs == nil {
+			response.AssigneeIDs = []uint{}
+		}
+		if response.Labels == nil {
+			response.Labels = []models.Label{}
+		}
+		if response.DependsOn == nil {
+			response.DependsOn = []uint{}
+		}
+		taskResponses = append(taskResponses, response)
 	}
 
 	// Final response
 	c.JSON(http.StatusOK, gin.H{
 		"tasks": taskResponses,
-		"page":  page,
-		"limit": limit,
+		"page":  filter.Page,
+		"limit": filter.Limit,
 		"total": total,
 	})
 }
 
 func (h *TaskHandler) GetTaskByID(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task id"})
 		return
 	}
-	task, err := h.SVC.GetTaskByID(uint(id))
+	task, err := h.SVC.GetTaskByID(actor, uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch task"})
 		return
 	}
 	c.JSON(http.StatusOK, task)
 }
 
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
-	id, _ := strconv.Atoi(c.Param("id"))
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
+		return
+	}
 	var task models.Task
-	if err := c.ShouldBindJSON(&task); err != nil || task.Title == "" || !models.IsValidStatus(task.Status) {
+	if err := c.ShouldBindJSON(&task); err != nil || task.Title == "" ||
+		(task.Priority != "" && !models.IsValidPriority(task.Priority)) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
 		return
 	}
 	task.ID = uint(id)
+	task.Progress = nil
 	task.UpdatedAt = time.Now()
-	if err := h.SVC.UpdateTask(&task); err != nil {
+	// force=true starts a task even while it is blocked
+	force := c.Query("force") == "true"
+	if err := h.SVC.UpdateTask(actor, &task, force); err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		if writeProjectTaskError(c, err) || writeSubtaskError(c, err) || writeDependencyError(c, err) ||
+			writeWorkflowError(c, err) || writeScheduleError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "update failed"})
 		return
 	}
@@ -161,6 +558,10 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 }
 
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid task ID"})
@@ -168,14 +569,18 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	}
 
 	// Check if task exists first
-	task, err := h.SVC.GetTaskByID(uint(id))
+	task, err := h.SVC.GetTaskByID(actor, uint(id))
 	if err != nil || task == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
 		return
 	}
 
 	// Proceed to delete
-	if err := h.SVC.DeleteTask(uint(id)); err != nil {
+	if err := h.SVC.DeleteTask(actor, uint(id)); err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete task"})
 		return
 	}
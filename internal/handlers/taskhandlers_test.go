@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,14 +15,28 @@ import (
 	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
 	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
 	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/ratheeshkumar25/task-mgt/utility"
 	"github.com/stretchr/testify/assert"
 )
 
+// testUserID is the authenticated user injected by setupTestRouter
+const testUserID uint = 1
+
+var testActor = models.Actor{UserID: testUserID, Role: models.RoleUser}
+
 // Setup function for initializing gin context and router
 func setupTestRouter() (*gin.Engine, *gin.RouterGroup) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	apiGroup := router.Group("/api/v1")
+	// Stand in for middleware.AuthMiddleware
+	apiGroup.Use(func(c *gin.Context) {
+		c.Set("userID", testUserID)
+		c.Set("username", "test@example.com")
+		c.Set("role", string(models.RoleUser))
+		c.Next()
+	})
 	return router, apiGroup
 }
 
@@ -32,7 +48,7 @@ func TestRegister(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.POST("/register", h.Register)
 
 	user := models.Users{
@@ -61,11 +77,11 @@ func TestLogin(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.POST("/login", h.Login)
 
 	loginData := map[string]string{"username": "test@example.com", "password": "password123"}
-	mockService.EXPECT().LoginUser("test@example.com", "password123").Return("mockToken", nil)
+	mockService.EXPECT().LoginUser("test@example.com", "password123", gomock.Any()).Return(&utility.LoginResult{TokenPair: &utility.TokenPair{AccessToken: "mockToken", RefreshToken: "mockRefresh"}}, nil)
 
 	reqBody, _ := json.Marshal(loginData)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewBuffer(reqBody))
@@ -76,6 +92,67 @@ func TestLogin(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "mockToken")
+	assert.Contains(t, w.Body.String(), "mockRefresh")
+}
+
+// Test Login Handler while throttled
+func TestLogin_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/login", h.Login)
+
+	mockService.EXPECT().LoginUser("test@example.com", "password123", gomock.Any()).
+		Return(nil, &services.LoginThrottledError{RetryAfter: 4 * time.Second})
+
+	reqBody, _ := json.Marshal(map[string]string{"username": "test@example.com", "password": "password123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "4", w.Header().Get("Retry-After"))
+}
+
+// Test Refresh Token Handler
+func TestRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/token/refresh", h.RefreshToken)
+
+	gomock.InOrder(
+		mockService.EXPECT().RefreshToken("refresh-1").Return(&utility.TokenPair{AccessToken: "newToken", RefreshToken: "refresh-2"}, nil),
+		mockService.EXPECT().RefreshToken("refresh-1").Return(nil, services.ErrRefreshTokenReuse),
+	)
+
+	reqBody, _ := json.Marshal(map[string]string{"refresh_token": "refresh-1"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/token/refresh", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "refresh-2")
+
+	// Replaying the same refresh token is rejected
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/token/refresh", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "reuse")
 }
 
 // Test Create Task
@@ -86,7 +163,7 @@ func TestCreateTask(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.POST("/tasks", h.CreateTask)
 
 	task := models.Task{
@@ -96,7 +173,10 @@ func TestCreateTask(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 
-	mockService.EXPECT().CreateTask(gomock.Any()).Return(nil)
+	mockService.EXPECT().CreateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, testUserID, task.UserID)
+		return nil
+	})
 
 	reqBody, _ := json.Marshal(task)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewBuffer(reqBody))
@@ -109,6 +189,26 @@ func TestCreateTask(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Test Task")
 }
 
+// Test Create Task Handler with invalid reminders
+func TestCreateTask_InvalidReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/tasks", h.CreateTask)
+
+	mockService.EXPECT().CreateTask(gomock.Any()).Return(services.ErrInvalidReminder)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", strings.NewReader(`{"title":"Chore","reminderMinutes":[0]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // // Test Get All Tasks
 func TestGetAllTasks(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -117,7 +217,7 @@ func TestGetAllTasks(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.GET("/tasks", h.GetAllTasks)
 
 	mockTasks := []models.Task{
@@ -125,7 +225,7 @@ func TestGetAllTasks(t *testing.T) {
 		{ID: 2, Title: "Task 2", Status: models.TaskStatusPending, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
-	mockService.EXPECT().GetAllTasks("", "", "due_date", "asc", 1, 10).Return(mockTasks, int64(2), nil)
+	mockService.EXPECT().GetAllTasks(testActor, models.TaskFilter{SortBy: "due_date", SortOrder: "asc", Page: 1, Limit: 10}).Return(mockTasks, int64(2), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks", nil)
 	w := httptest.NewRecorder()
@@ -136,6 +236,98 @@ func TestGetAllTasks(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Task 2")
 }
 
+// Test Get All Tasks Handler with an assignee filter
+func TestGetAllTasks_AssigneeFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks", h.GetAllTasks)
+
+	mockService.EXPECT().GetAllTasks(testActor, gomock.Any()).DoAndReturn(
+		func(_ models.Actor, filter models.TaskFilter) ([]models.Task, int64, error) {
+			assert.Equal(t, testUserID, filter.AssigneeID)
+			return nil, int64(0), nil
+		})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?assignee=me", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?assignee=someone", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Test Get All Tasks Handler with priority filter and sorting
+func TestGetAllTasks_PriorityAndSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks", h.GetAllTasks)
+
+	mockService.EXPECT().GetAllTasks(testActor, models.TaskFilter{
+		Priorities: []models.TaskPriority{models.TaskPriorityHigh, models.TaskPriorityUrgent},
+		SortBy:     models.SortSmart, SortOrder: "asc", Page: 1, Limit: 10,
+	}).Return(nil, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?priority=high,urgent&sort_by=smart", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Anything outside the whitelist never reaches the query
+	for _, query := range []url.Values{
+		{"sort_by": {"id; DROP TABLE tasks"}},
+		{"sort_by": {"title"}, "sort_order": {"asc, id"}},
+		{"priority": {"critical"}},
+	} {
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?"+query.Encode(), nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+// Test Get All Tasks Handler with label filter
+func TestGetAllTasks_LabelFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks", h.GetAllTasks)
+
+	label := models.Label{ID: 3, UserID: testUserID, Name: "bug", Color: "#ff0000"}
+	mockService.EXPECT().GetAllTasks(testActor, models.TaskFilter{
+		Labels: []string{"bug", "frontend"}, LabelMode: models.LabelModeAll,
+		SortBy: "due_date", SortOrder: "asc", Page: 1, Limit: 10,
+	}).Return([]models.Task{{ID: 1, Title: "Fix header", Labels: []models.Label{label}}}, int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks?labels=bug,%20frontend&label_mode=all", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"Labels":[{"id":3`)
+	assert.Contains(t, w.Body.String(), `"AssigneeIDs":[]`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/tasks?labels=bug&label_mode=some", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // Test Get Task by ID
 func TestGetTaskByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -144,12 +336,12 @@ func TestGetTaskByID(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.GET("/tasks/:id", h.GetTaskByID)
 
 	mockTask := models.Task{ID: 1, Title: "Task 1", Status: models.TaskStatusPending, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 
-	mockService.EXPECT().GetTaskByID(uint(1)).Return(&mockTask, nil)
+	mockService.EXPECT().GetTaskByID(testActor, uint(1)).Return(&mockTask, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1", nil)
 	w := httptest.NewRecorder()
@@ -167,12 +359,12 @@ func TestUpdateTask(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.PUT("/tasks/:id", h.UpdateTask)
 
 	updatedTask := models.Task{ID: 1, Title: "Updated Task", Status: models.TaskStatusCompleted, UpdatedAt: time.Now()}
 
-	mockService.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(nil)
 
 	reqBody, _ := json.Marshal(updatedTask)
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", bytes.NewBuffer(reqBody))
@@ -193,11 +385,11 @@ func TestDeleteTask(t *testing.T) {
 	mockService := mocks.NewMockTaskServiceInter(ctrl)
 	router, apiGroup := setupTestRouter()
 
-	h := handlers.TaskHandler{SVC: mockService, JWTSecret: "secret"}
+	h := handlers.TaskHandler{SVC: mockService}
 	apiGroup.DELETE("/tasks/:id", h.DeleteTask)
 
-	mockService.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1}, nil)
-	mockService.EXPECT().DeleteTask(uint(1)).Return(nil)
+	mockService.EXPECT().GetTaskByID(testActor, uint(1)).Return(&models.Task{ID: 1}, nil)
+	mockService.EXPECT().DeleteTask(testActor, uint(1)).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/1", nil)
 	w := httptest.NewRecorder()
@@ -206,3 +398,53 @@ func TestDeleteTask(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "task deleted successfully")
 }
+
+// Test another user's task is reported as missing
+func TestGetTaskByID_OtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id", h.GetTaskByID)
+	apiGroup.PUT("/tasks/:id", h.UpdateTask)
+
+	mockService.EXPECT().GetTaskByID(testActor, uint(2)).Return(nil, models.ErrTaskNotFound)
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(models.ErrTaskNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	reqBody, _ := json.Marshal(models.Task{Title: "Hijack", Status: models.TaskStatusPending})
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/tasks/2", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test task routes reject requests without an authenticated user
+func TestCreateTask_Unauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	router.POST("/api/v1/tasks", h.CreateTask)
+
+	reqBody, _ := json.Marshal(models.Task{Title: "Test Task", Status: models.TaskStatusPending})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
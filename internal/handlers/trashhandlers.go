@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+func (h *TaskHandler) GetTrash(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	page, limit := pageQuery(c)
+
+	tasks, total, err := h.SVC.GetTrash(actor, page, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch trash"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"tasks": tasks,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+func (h *TaskHandler) RestoreTask(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+
+	task, err := h.SVC.RestoreTask(actor, id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore task"})
+		return
+	}
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) PurgeTask(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+
+	if err := h.SVC.PurgeTask(actor, id); err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found in trash"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to purge task"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "task purged"})
+}
+
+func (h *TaskHandler) EmptyTrash(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	purged, err := h.SVC.EmptyTrash(actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to empty trash"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"purged": purged})
+}
@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Get Trash Handler
+func TestGetTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/trash", h.GetTrash)
+
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockService.EXPECT().GetTrash(testActor, 1, 50).Return([]models.TrashedTask{
+		{Task: models.Task{ID: 7, Title: "Old"}, DeletedAt: deletedAt},
+	}, int64(1), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"deletedAt":"2024-05-01T12:00:00Z"`)
+	assert.NotContains(t, w.Body.String(), `"purgeAt"`)
+}
+
+// Test Restore Task Handler
+func TestRestoreTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/tasks/:id/restore", h.RestoreTask)
+
+	mockService.EXPECT().RestoreTask(testActor, uint(7)).Return(&models.Task{ID: 7, Title: "Old"}, nil)
+	mockService.EXPECT().RestoreTask(testActor, uint(8)).Return(nil, models.ErrTaskNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/tasks/7/restore", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":7`)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/tasks/8/restore", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test Purge Task Handler
+func TestPurgeTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.DELETE("/tasks/trash/:id", h.PurgeTask)
+	apiGroup.DELETE("/tasks/trash", h.EmptyTrash)
+
+	mockService.EXPECT().PurgeTask(testActor, uint(7)).Return(nil)
+	mockService.EXPECT().EmptyTrash(testActor).Return(int64(2), nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/trash/7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/tasks/trash", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"purged":2`)
+}
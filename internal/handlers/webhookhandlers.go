@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// webhookBody is the request body of the webhook endpoints. The secret is only
+// read on creation.
+type webhookBody struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// subscription converts the body, subscriptions are active unless stated otherwise
+func (b webhookBody) subscription() *models.WebhookSubscription {
+	sub := &models.WebhookSubscription{URL: b.URL, Secret: b.Secret, Events: b.Events, Active: true}
+	if b.Active != nil {
+		sub.Active = *b.Active
+	}
+	return sub
+}
+
+// writeWebhookError maps service errors of the webhook endpoints to responses
+func writeWebhookError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+	case errors.Is(err, models.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook delivery not found"})
+	case errors.Is(err, services.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWebhooksDisabled):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+func (h *TaskHandler) CreateWebhook(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var body webhookBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	created, err := h.SVC.CreateWebhook(actor, body.subscription())
+	if err != nil {
+		writeWebhookError(c, err, "failed to create webhook")
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *TaskHandler) ListWebhooks(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	webhooks, err := h.SVC.ListWebhooks(actor)
+	if err != nil {
+		writeWebhookError(c, err, "failed to fetch webhooks")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"webhooks": webhooks})
+}
+
+func (h *TaskHandler) GetWebhook(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	sub, err := h.SVC.GetWebhook(actor, id)
+	if err != nil {
+		writeWebhookError(c, err, "failed to fetch webhook")
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *TaskHandler) UpdateWebhook(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	var body webhookBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	sub := body.subscription()
+	sub.ID = id
+	updated, err := h.SVC.UpdateWebhook(actor, sub)
+	if err != nil {
+		writeWebhookError(c, err, "failed to update webhook")
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *TaskHandler) DeleteWebhook(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	if err := h.SVC.DeleteWebhook(actor, id); err != nil {
+		writeWebhookError(c, err, "failed to delete webhook")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "webhook deleted successfully"})
+}
+
+func (h *TaskHandler) ListWebhookDeliveries(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	page, limit := pageQuery(c)
+
+	deliveries, total, err := h.SVC.ListWebhookDeliveries(actor, id, page, limit)
+	if err != nil {
+		writeWebhookError(c, err, "failed to fetch webhook deliveries")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"page":       page,
+		"limit":      limit,
+		"total":      total,
+	})
+}
+
+func (h *TaskHandler) RedeliverWebhook(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid webhook ID")
+	if !ok {
+		return
+	}
+	deliveryID, ok := uintParam(c, "deliveryId", "invalid delivery ID")
+	if !ok {
+		return
+	}
+	delivery, err := h.SVC.RedeliverWebhook(actor, id, deliveryID)
+	if err != nil {
+		writeWebhookError(c, err, "failed to redeliver webhook")
+		return
+	}
+	c.JSON(http.StatusAccepted, delivery)
+}
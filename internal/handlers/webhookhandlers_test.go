@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Create Webhook Handler
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/webhooks", h.CreateWebhook)
+
+	mockService.EXPECT().CreateWebhook(testActor, gomock.Any()).DoAndReturn(
+		func(actor models.Actor, sub *models.WebhookSubscription) (*models.CreatedWebhook, error) {
+			assert.Equal(t, "https://example.com/hook", sub.URL)
+			assert.Equal(t, []string{models.WebhookTaskCreated}, sub.Events)
+			assert.True(t, sub.Active)
+			sub.ID = 1
+			return &models.CreatedWebhook{WebhookSubscription: *sub, Secret: "generated-secret"}, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks",
+		strings.NewReader(`{"url": "https://example.com/hook", "events": ["task.created"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"secret":"generated-secret"`)
+
+	mockService.EXPECT().CreateWebhook(testActor, gomock.Any()).Return(nil, services.ErrInvalidWebhook)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(`{"url": "ftp://example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.EXPECT().CreateWebhook(testActor, gomock.Any()).Return(nil, services.ErrWebhooksDisabled)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(`{"url": "https://example.com/hook"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+// Test Get Webhook Handler
+func TestGetWebhook_HidesSecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/webhooks/:id", h.GetWebhook)
+
+	mockService.EXPECT().GetWebhook(testActor, uint(1)).Return(&models.WebhookSubscription{
+		ID: 1, URL: "https://example.com/hook", Secret: "hidden-secret", Active: true,
+	}, nil)
+	mockService.EXPECT().GetWebhook(testActor, uint(2)).Return(nil, models.ErrWebhookNotFound)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "hidden-secret")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/2", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// Test List Webhook Deliveries Handler
+func TestListWebhookDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/webhooks/:id/deliveries", h.ListWebhookDeliveries)
+
+	mockService.EXPECT().ListWebhookDeliveries(testActor, uint(1), 2, 10).Return([]models.WebhookDelivery{
+		{ID: 5, SubscriptionID: 1, Event: models.WebhookTaskUpdated, Payload: []byte(`{"event":"task.updated"}`),
+			Status: models.WebhookDeliveryFailed, Attempts: 8, LastStatusCode: 500},
+	}, int64(11), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/webhooks/1/deliveries?page=2&limit=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total":11`)
+	assert.Contains(t, w.Body.String(), `"status":"failed"`)
+}
+
+// Test Redeliver Webhook Handler
+func TestRedeliverWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", h.RedeliverWebhook)
+
+	original := uint(5)
+	mockService.EXPECT().RedeliverWebhook(testActor, uint(1), uint(5)).Return(&models.WebhookDelivery{
+		ID: 6, SubscriptionID: 1, Status: models.WebhookDeliveryPending, RedeliveryOf: &original,
+	}, nil)
+	mockService.EXPECT().RedeliverWebhook(testActor, uint(1), uint(9)).Return(nil, models.ErrWebhookDeliveryNotFound)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/1/deliveries/5/redeliver", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/1/deliveries/9/redeliver", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/1/deliveries/abc/redeliver", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+)
+
+// NewWellKnownHandler registers the unversioned discovery routes
+func NewWellKnownHandler(router gin.IRoutes, keys *utility.KeySet) {
+	router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		// Short cache so verifiers pick up a rotation quickly
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, keys.JWKS())
+	})
+}
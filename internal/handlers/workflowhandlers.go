@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+)
+
+// writeWorkflowError writes the response for status errors of the task endpoints
+// and reports whether err was one
+func writeWorkflowError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, services.ErrUnknownStatus):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrTransitionNotAllowed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *TaskHandler) GetWorkflow(c *gin.Context) {
+	workflow, err := h.SVC.GetWorkflow()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch workflow"})
+		return
+	}
+	c.JSON(http.StatusOK, workflow)
+}
+
+func (h *TaskHandler) UpdateWorkflow(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	var workflow models.Workflow
+	if err := c.ShouldBindJSON(&workflow); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := h.SVC.UpdateWorkflow(actor, &workflow); err != nil {
+		switch {
+		case errors.Is(err, models.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, models.ErrStatusInUse):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, workflow)
+}
+
+func (h *TaskHandler) GetTaskTransitions(c *gin.Context) {
+	actor, ok := currentActor(c)
+	if !ok {
+		return
+	}
+	id, ok := uintParam(c, "id", "invalid task ID")
+	if !ok {
+		return
+	}
+	transitions, err := h.SVC.GetTaskTransitions(actor, id)
+	if err != nil {
+		if errors.Is(err, models.ErrTaskNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "task not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch transitions"})
+		return
+	}
+	c.JSON(http.StatusOK, transitions)
+}
@@ -0,0 +1,84 @@
+package handlers_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/handlers"
+	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test Get Task Transitions Handler
+func TestGetTaskTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.GET("/tasks/:id/transitions", h.GetTaskTransitions)
+
+	mockService.EXPECT().GetTaskTransitions(testActor, uint(1)).Return(&models.TaskTransitions{
+		Status:   models.TaskStatusPending,
+		Category: models.StatusCategoryTodo,
+		Next:     []models.WorkflowStatus{{Name: models.TaskStatusInProgress, Category: models.StatusCategoryDoing, Position: 2}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/1/transitions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"next":[{"name":"In Progress","category":"doing","position":2}]`)
+}
+
+// Test Update Workflow Handler
+func TestUpdateWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/admin/workflow", h.UpdateWorkflow)
+
+	mockService.EXPECT().UpdateWorkflow(testActor, gomock.Any()).
+		Return(fmt.Errorf("%w: [Review]", models.ErrStatusInUse))
+
+	body := `{"statuses": [{"name": "Open", "category": "todo"}, {"name": "Done", "category": "done"}],
+		"transitions": [{"from": "Open", "to": "Done"}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/workflow", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// Test Update Task Handler with a transition outside the workflow
+func TestUpdateTask_TransitionNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockTaskServiceInter(ctrl)
+	router, apiGroup := setupTestRouter()
+
+	h := handlers.TaskHandler{SVC: mockService}
+	apiGroup.PUT("/tasks/:id", h.UpdateTask)
+
+	mockService.EXPECT().UpdateTask(testActor, gomock.Any(), false).Return(services.ErrTransitionNotAllowed)
+
+	body := bytes.NewBufferString(`{"title": "Ship", "status": "Pending"}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/tasks/1", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,20 @@
+package mailer
+
+import "log"
+
+// LogMailer writes emails to a logger instead of sending them. It is used when
+// no SMTP relay is configured, so links can be picked up from app.log offline.
+type LogMailer struct {
+	Logger *log.Logger
+}
+
+// NewLogMailer creates a mailer writing to logger
+func NewLogMailer(logger *log.Logger) *LogMailer {
+	return &LogMailer{Logger: logger}
+}
+
+// Send implements Mailer
+func (m *LogMailer) Send(to, subject, body string) error {
+	m.Logger.Printf("mail to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
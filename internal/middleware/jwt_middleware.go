@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,19 +10,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	redis "github.com/go-redis/redis/v8"
-	"github.com/golang-jwt/jwt"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
 	"github.com/ratheeshkumar25/task-mgt/utility"
 )
 
 func RateLimitMiddleware(redisClient *redis.Client, maxRequests int, duration time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := context.Background()
-		userID := fmt.Sprintf("%v", c.GetString("userID"))
-		if userID == "" || userID == "0" {
-			userID = c.ClientIP() // fallback to IP
+		key := fmt.Sprintf("rate_limit:ip:%s", c.ClientIP()) // fallback to IP
+		if userID := c.GetUint("userID"); userID != 0 {
+			key = fmt.Sprintf("rate_limit:user:%d", userID)
 		}
 
-		key := fmt.Sprintf("rate_limit:%v", userID)
 		count, err := redisClient.Incr(ctx, key).Result()
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "rate limiter internal error"})
@@ -47,40 +47,140 @@ func RateLimitMiddleware(redisClient *redis.Client, maxRequests int, duration ti
 	}
 }
 
-// AuthMiddleware checks for valid JWT in Authorization header and sets userID in context
-func AuthMiddleware(secretKey string) gin.HandlerFunc {
+// APIKeyAuthenticator resolves X-API-Key headers to their owner
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(raw string) (*models.Users, *models.APIKey, error)
+}
+
+// TokenUserVerifier loads the user of a verified token, rejecting deleted and
+// disabled accounts with a *utility.TokenError
+type TokenUserVerifier interface {
+	VerifyTokenUser(claims *utility.UserClaim) (*models.Users, error)
+}
+
+// abortAuth rejects the request with the error message and its machine-readable code
+func abortAuth(c *gin.Context, err error) {
+	var tokenErr *utility.TokenError
+	if errors.As(err, &tokenErr) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": tokenErr.Message, "code": tokenErr.Code})
+	} else {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "authentication check failed"})
+	}
+	c.Abort()
+}
+
+// AuthMiddleware checks for valid JWT in Authorization header and sets userID in context.
+// Tokens are verified against the key set, so any key still in its grace period works,
+// and their iss, aud, exp, nbf, jti and payload hash are checked. Tokens on the redis
+// denylist (logout, logout-all, revoked session) are rejected. When users is not nil
+// the token's user has to still exist and be enabled, and its current role is used.
+// When apiKeys is not nil an X-API-Key header is accepted instead of a JWT; its
+// scopes are stored under "apiKeyScopes" for RequireScopes.
+func AuthMiddleware(keys *utility.KeySet, store *utility.TokenStore, users TokenUserVerifier, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if rawKey := c.GetHeader("X-API-Key"); rawKey != "" && apiKeys != nil {
+			user, key, err := apiKeys.AuthenticateAPIKey(rawKey)
+			if err != nil {
+				abortAuth(c, utility.NewTokenError(utility.ErrCodeAPIKeyInvalid, "invalid or expired api key"))
+				return
+			}
+			c.Set("userID", user.ID)
+			c.Set("username", user.Username)
+			c.Set("role", string(user.Role))
+			c.Set("apiKeyScopes", key.ScopeList())
+			c.Next()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid authorization header"})
-			c.Abort()
+			abortAuth(c, utility.NewTokenError(utility.ErrCodeTokenMissing, "missing or invalid authorization header"))
 			return
 		}
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.ParseWithClaims(tokenStr, &utility.UserClaim{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method")
-			}
-			return []byte(secretKey), nil
-		})
-
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
+		claims, err := utility.ParseToken(keys, tokenStr)
+		if err != nil {
+			abortAuth(c, err)
 			return
 		}
 
-		claims, ok := token.Claims.(*utility.UserClaim)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
-			c.Abort()
+		// Refresh tokens are only accepted by /token/refresh
+		if claims.TokenType != utility.AccessToken {
+			abortAuth(c, utility.NewTokenError(utility.ErrCodeTokenType, "invalid token type"))
 			return
 		}
 
+		if store != nil {
+			revoked, err := store.IsRevoked(c.Request.Context(), claims)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "token revocation check failed"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				abortAuth(c, utility.NewTokenError(utility.ErrCodeTokenRevoked, "token has been revoked"))
+				return
+			}
+		}
+
+		role := claims.Role
+		if users != nil {
+			user, err := users.VerifyTokenUser(claims)
+			if err != nil {
+				abortAuth(c, err)
+				return
+			}
+			// A role change applies immediately, not only to the next token
+			role = string(user.Role)
+		}
+
 		// Attach UserID to request context
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
+		c.Set("role", role)
+		c.Set("claims", claims)
 		c.Next()
 	}
 }
+
+// RequireScopes limits API key requests to the key's scopes: safe methods need
+// readScope, everything else writeScope. JWT requests are not restricted.
+func RequireScopes(readScope, writeScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, isAPIKey := c.Get("apiKeyScopes")
+		if !isAPIKey {
+			c.Next()
+			return
+		}
+
+		required := writeScope
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			required = readScope
+		}
+		scopes, _ := value.([]string)
+		for _, scope := range scopes {
+			if scope == required {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "api key lacks the " + required + " scope"})
+		c.Abort()
+	}
+}
+
+// RequireRole only lets requests through whose role, set by AuthMiddleware, is one of roles
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		c.Abort()
+	}
+}
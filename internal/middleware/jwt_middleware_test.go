@@ -0,0 +1,116 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ratheeshkumar25/task-mgt/internal/middleware"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubAPIKeys accepts a single read-only key
+type stubAPIKeys struct{}
+
+func (stubAPIKeys) AuthenticateAPIKey(raw string) (*models.Users, *models.APIKey, error) {
+	if raw != "tm_good" {
+		return nil, nil, errors.New("invalid")
+	}
+	return &models.Users{ID: 5, Username: "ci@example.com", Role: models.RoleUser},
+		&models.APIKey{Scopes: models.ScopeTasksRead}, nil
+}
+
+func setupAPIKeyRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(
+		middleware.AuthMiddleware(utility.NewHMACKeySet("secret"), nil, nil, stubAPIKeys{}),
+		middleware.RequireScopes(models.ScopeTasksRead, models.ScopeTasksWrite),
+	)
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"userID": c.GetUint("userID")})
+	}
+	router.GET("/tasks", handler)
+	router.POST("/tasks", handler)
+	return router
+}
+
+func TestAuthMiddleware_APIKeyScopes(t *testing.T) {
+	router := setupAPIKeyRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("X-API-Key", "tm_good")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"userID":5`)
+
+	// The key is read-only
+	req = httptest.NewRequest(http.MethodPost, "/tasks", nil)
+	req.Header.Set("X-API-Key", "tm_good")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/tasks", nil)
+	req.Header.Set("X-API-Key", "tm_bad")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+// stubUsers knows user 1; user 2 is disabled
+type stubUsers struct{}
+
+func (stubUsers) VerifyTokenUser(claims *utility.UserClaim) (*models.Users, error) {
+	if claims.UserID == 2 {
+		return nil, utility.NewTokenError(utility.ErrCodeUserDisabled, "account is disabled")
+	}
+	return &models.Users{ID: claims.UserID, Username: claims.Username, Role: models.RoleManager}, nil
+}
+
+func TestAuthMiddleware_ErrorCodes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	keys := utility.NewHMACKeySet("secret")
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(keys, nil, stubUsers{}, nil))
+	router.GET("/me", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"role": c.GetString("role")})
+	})
+
+	request := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	active, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+	disabled, err := utility.GenerateTokenPair(keys, "gone@example.com", 2, "user", "")
+	assert.NoError(t, err)
+
+	// The stored role wins over the role in the token
+	w := request("Bearer " + active.AccessToken)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"manager"`)
+
+	cases := map[string]string{
+		"":                               utility.ErrCodeTokenMissing,
+		"Bearer not-a-jwt":               utility.ErrCodeTokenMalformed,
+		"Bearer " + active.RefreshToken:  utility.ErrCodeTokenType,
+		"Bearer " + disabled.AccessToken: utility.ErrCodeUserDisabled,
+	}
+	for header, code := range cases {
+		w := request(header)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, header)
+		assert.Contains(t, w.Body.String(), `"code":"`+code+`"`, header)
+	}
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ratheeshkumar25/task-mgt/internal/models"
@@ -34,6 +35,207 @@ func (m *MockTaskRepoInter) EXPECT() *MockTaskRepoInterMockRecorder {
 	return m.recorder
 }
 
+// AddProjectMember mocks base method.
+func (m *MockTaskRepoInter) AddProjectMember(projectID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectMember", projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProjectMember indicates an expected call of AddProjectMember.
+func (mr *MockTaskRepoInterMockRecorder) AddProjectMember(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectMember", reflect.TypeOf((*MockTaskRepoInter)(nil).AddProjectMember), projectID, userID)
+}
+
+// AddTaskDependency mocks base method.
+func (m *MockTaskRepoInter) AddTaskDependency(dep *models.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskDependency", dep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskDependency indicates an expected call of AddTaskDependency.
+func (mr *MockTaskRepoInterMockRecorder) AddTaskDependency(dep interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskDependency", reflect.TypeOf((*MockTaskRepoInter)(nil).AddTaskDependency), dep)
+}
+
+// AttachLabels mocks base method.
+func (m *MockTaskRepoInter) AttachLabels(taskID uint, labelIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLabels", taskID, labelIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachLabels indicates an expected call of AttachLabels.
+func (mr *MockTaskRepoInterMockRecorder) AttachLabels(taskID, labelIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLabels", reflect.TypeOf((*MockTaskRepoInter)(nil).AttachLabels), taskID, labelIDs)
+}
+
+// ClaimReminder mocks base method.
+func (m *MockTaskRepoInter) ClaimReminder(reminder *models.TaskReminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReminder", reminder)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReminder indicates an expected call of ClaimReminder.
+func (mr *MockTaskRepoInterMockRecorder) ClaimReminder(reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReminder", reflect.TypeOf((*MockTaskRepoInter)(nil).ClaimReminder), reminder)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockTaskRepoInter) ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", now, leaseUntil, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockTaskRepoInterMockRecorder) ClaimWebhookDeliveries(now, leaseUntil, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockTaskRepoInter)(nil).ClaimWebhookDeliveries), now, leaseUntil, limit)
+}
+
+// CountTasksByStatus mocks base method.
+func (m *MockTaskRepoInter) CountTasksByStatus(userID uint) (map[models.TaskStatus]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTasksByStatus", userID)
+	ret0, _ := ret[0].(map[models.TaskStatus]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTasksByStatus indicates an expected call of CountTasksByStatus.
+func (mr *MockTaskRepoInterMockRecorder) CountTasksByStatus(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTasksByStatus", reflect.TypeOf((*MockTaskRepoInter)(nil).CountTasksByStatus), userID)
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockTaskRepoInter) CountUnreadNotifications(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockTaskRepoInterMockRecorder) CountUnreadNotifications(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockTaskRepoInter)(nil).CountUnreadNotifications), userID)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockTaskRepoInter) CreateAPIKey(key *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockTaskRepoInterMockRecorder) CreateAPIKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateAPIKey), key)
+}
+
+// CreateAttachment mocks base method.
+func (m *MockTaskRepoInter) CreateAttachment(attachment *models.Attachment, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAttachment", attachment, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAttachment indicates an expected call of CreateAttachment.
+func (mr *MockTaskRepoInterMockRecorder) CreateAttachment(attachment, quota interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAttachment", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateAttachment), attachment, quota)
+}
+
+// CreateComment mocks base method.
+func (m *MockTaskRepoInter) CreateComment(comment *models.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockTaskRepoInterMockRecorder) CreateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateComment), comment)
+}
+
+// CreateLabel mocks base method.
+func (m *MockTaskRepoInter) CreateLabel(label *models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockTaskRepoInterMockRecorder) CreateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateLabel), label)
+}
+
+// CreateNotifications mocks base method.
+func (m *MockTaskRepoInter) CreateNotifications(notifications []models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotifications", notifications)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotifications indicates an expected call of CreateNotifications.
+func (mr *MockTaskRepoInterMockRecorder) CreateNotifications(notifications interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotifications", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateNotifications), notifications)
+}
+
+// CreateOccurrence mocks base method.
+func (m *MockTaskRepoInter) CreateOccurrence(task *models.Task) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOccurrence", task)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOccurrence indicates an expected call of CreateOccurrence.
+func (mr *MockTaskRepoInterMockRecorder) CreateOccurrence(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOccurrence", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateOccurrence), task)
+}
+
+// CreateProject mocks base method.
+func (m *MockTaskRepoInter) CreateProject(project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockTaskRepoInterMockRecorder) CreateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateProject), project)
+}
+
 // CreateTask mocks base method.
 func (m *MockTaskRepoInter) CreateTask(task *models.Task) error {
 	m.ctrl.T.Helper()
@@ -48,6 +250,20 @@ func (mr *MockTaskRepoInterMockRecorder) CreateTask(task interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateTask), task)
 }
 
+// CreateTaskHistory mocks base method.
+func (m *MockTaskRepoInter) CreateTaskHistory(entry *models.TaskHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskHistory", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskHistory indicates an expected call of CreateTaskHistory.
+func (mr *MockTaskRepoInterMockRecorder) CreateTaskHistory(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskHistory", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateTaskHistory), entry)
+}
+
 // CreateUser mocks base method.
 func (m *MockTaskRepoInter) CreateUser(user *models.Users) error {
 	m.ctrl.T.Helper()
@@ -62,18 +278,174 @@ func (mr *MockTaskRepoInterMockRecorder) CreateUser(user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateUser), user)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockTaskRepoInter) CreateWebhook(sub *models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockTaskRepoInterMockRecorder) CreateWebhook(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateWebhook), sub)
+}
+
+// CreateWebhookDeliveries mocks base method.
+func (m *MockTaskRepoInter) CreateWebhookDeliveries(deliveries []models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookDeliveries", deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookDeliveries indicates an expected call of CreateWebhookDeliveries.
+func (mr *MockTaskRepoInterMockRecorder) CreateWebhookDeliveries(deliveries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookDeliveries", reflect.TypeOf((*MockTaskRepoInter)(nil).CreateWebhookDeliveries), deliveries)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockTaskRepoInter) DeleteAttachment(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockTaskRepoInterMockRecorder) DeleteAttachment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteAttachment), id)
+}
+
+// DeleteComment mocks base method.
+func (m *MockTaskRepoInter) DeleteComment(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockTaskRepoInterMockRecorder) DeleteComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteComment), id)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockTaskRepoInter) DeleteLabel(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockTaskRepoInterMockRecorder) DeleteLabel(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteLabel), id)
+}
+
+// DeleteOrphanedAttachments mocks base method.
+func (m *MockTaskRepoInter) DeleteOrphanedAttachments() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedAttachments")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphanedAttachments indicates an expected call of DeleteOrphanedAttachments.
+func (mr *MockTaskRepoInterMockRecorder) DeleteOrphanedAttachments() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedAttachments", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteOrphanedAttachments))
+}
+
+// DeleteProject mocks base method.
+func (m *MockTaskRepoInter) DeleteProject(id uint, detach []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", id, detach)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockTaskRepoInterMockRecorder) DeleteProject(id, detach interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteProject), id, detach)
+}
+
 // DeleteTask mocks base method.
-func (m *MockTaskRepoInter) DeleteTask(id uint) error {
+func (m *MockTaskRepoInter) DeleteTask(userID, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", id)
+	ret := m.ctrl.Call(m, "DeleteTask", userID, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskRepoInterMockRecorder) DeleteTask(id interface{}) *gomock.Call {
+func (mr *MockTaskRepoInterMockRecorder) DeleteTask(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteTask), userID, id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockTaskRepoInter) DeleteUser(userID uint, reassignTo *uint, actorID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userID, reassignTo, actorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockTaskRepoInterMockRecorder) DeleteUser(userID, reassignTo, actorID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteUser), userID, reassignTo, actorID)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockTaskRepoInter) DeleteWebhook(id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockTaskRepoInterMockRecorder) DeleteWebhook(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteTask), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockTaskRepoInter)(nil).DeleteWebhook), id)
+}
+
+// DetachLabel mocks base method.
+func (m *MockTaskRepoInter) DetachLabel(taskID, labelID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLabel", taskID, labelID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachLabel indicates an expected call of DetachLabel.
+func (mr *MockTaskRepoInterMockRecorder) DetachLabel(taskID, labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLabel", reflect.TypeOf((*MockTaskRepoInter)(nil).DetachLabel), taskID, labelID)
+}
+
+// FindLabelByName mocks base method.
+func (m *MockTaskRepoInter) FindLabelByName(userID uint, name string) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLabelByName", userID, name)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLabelByName indicates an expected call of FindLabelByName.
+func (mr *MockTaskRepoInterMockRecorder) FindLabelByName(userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLabelByName", reflect.TypeOf((*MockTaskRepoInter)(nil).FindLabelByName), userID, name)
 }
 
 // FindUserByID mocks base method.
@@ -91,77 +463,964 @@ func (mr *MockTaskRepoInterMockRecorder) FindUserByID(userID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByID", reflect.TypeOf((*MockTaskRepoInter)(nil).FindUserByID), userID)
 }
 
-// GetFilteredTasks mocks base method.
-func (m *MockTaskRepoInter) GetFilteredTasks(status, dueDateAfter, sortBy, sortOrder string, page, limit int) ([]models.Task, int64, error) {
+// GetAPIKeyByPrefix mocks base method.
+func (m *MockTaskRepoInter) GetAPIKeyByPrefix(prefix string) (*models.APIKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilteredTasks", status, dueDateAfter, sortBy, sortOrder, page, limit)
-	ret0, _ := ret[0].([]models.Task)
-	ret1, _ := ret[1].(int64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "GetAPIKeyByPrefix", prefix)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// GetFilteredTasks indicates an expected call of GetFilteredTasks.
-func (mr *MockTaskRepoInterMockRecorder) GetFilteredTasks(status, dueDateAfter, sortBy, sortOrder, page, limit interface{}) *gomock.Call {
+// GetAPIKeyByPrefix indicates an expected call of GetAPIKeyByPrefix.
+func (mr *MockTaskRepoInterMockRecorder) GetAPIKeyByPrefix(prefix interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredTasks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetFilteredTasks), status, dueDateAfter, sortBy, sortOrder, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByPrefix", reflect.TypeOf((*MockTaskRepoInter)(nil).GetAPIKeyByPrefix), prefix)
 }
 
-// GetTaskByID mocks base method.
-func (m *MockTaskRepoInter) GetTaskByID(id uint) (*models.Task, error) {
+// GetActiveWebhooks mocks base method.
+func (m *MockTaskRepoInter) GetActiveWebhooks(event string) ([]models.WebhookSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskByID", id)
-	ret0, _ := ret[0].(*models.Task)
+	ret := m.ctrl.Call(m, "GetActiveWebhooks", event)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTaskByID indicates an expected call of GetTaskByID.
-func (mr *MockTaskRepoInterMockRecorder) GetTaskByID(id interface{}) *gomock.Call {
+// GetActiveWebhooks indicates an expected call of GetActiveWebhooks.
+func (mr *MockTaskRepoInterMockRecorder) GetActiveWebhooks(event interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTaskByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWebhooks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetActiveWebhooks), event)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockTaskRepoInter) GetUserByUsername(usename string) (*models.Users, error) {
+// GetAttachmentByID mocks base method.
+func (m *MockTaskRepoInter) GetAttachmentByID(id uint) (*models.Attachment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", usename)
-	ret0, _ := ret[0].(*models.Users)
+	ret := m.ctrl.Call(m, "GetAttachmentByID", id)
+	ret0, _ := ret[0].(*models.Attachment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockTaskRepoInterMockRecorder) GetUserByUsername(usename interface{}) *gomock.Call {
+// GetAttachmentByID indicates an expected call of GetAttachmentByID.
+func (mr *MockTaskRepoInterMockRecorder) GetAttachmentByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUserByUsername), usename)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetAttachmentByID), id)
 }
 
-// GetUserList mocks base method.
-func (m *MockTaskRepoInter) GetUserList() ([]*models.Users, error) {
+// GetAttachmentUsage mocks base method.
+func (m *MockTaskRepoInter) GetAttachmentUsage(userID uint) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserList")
-	ret0, _ := ret[0].([]*models.Users)
+	ret := m.ctrl.Call(m, "GetAttachmentUsage", userID)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserList indicates an expected call of GetUserList.
-func (mr *MockTaskRepoInterMockRecorder) GetUserList() *gomock.Call {
+// GetAttachmentUsage indicates an expected call of GetAttachmentUsage.
+func (mr *MockTaskRepoInterMockRecorder) GetAttachmentUsage(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserList", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUserList))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentUsage", reflect.TypeOf((*MockTaskRepoInter)(nil).GetAttachmentUsage), userID)
 }
 
-// UpdateTask mocks base method.
-func (m *MockTaskRepoInter) UpdateTask(task *models.Task) error {
+// GetAttachments mocks base method.
+func (m *MockTaskRepoInter) GetAttachments(taskID uint) ([]models.Attachment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", task)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetAttachments", taskID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskRepoInterMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockTaskRepoInterMockRecorder) GetAttachments(taskID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockTaskRepoInter)(nil).GetAttachments), taskID)
+}
+
+// GetCommentByID mocks base method.
+func (m *MockTaskRepoInter) GetCommentByID(id uint) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentByID", id)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentByID indicates an expected call of GetCommentByID.
+func (mr *MockTaskRepoInterMockRecorder) GetCommentByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetCommentByID), id)
+}
+
+// GetCommentEdits mocks base method.
+func (m *MockTaskRepoInter) GetCommentEdits(commentID uint) ([]models.CommentEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentEdits", commentID)
+	ret0, _ := ret[0].([]models.CommentEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentEdits indicates an expected call of GetCommentEdits.
+func (mr *MockTaskRepoInterMockRecorder) GetCommentEdits(commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentEdits", reflect.TypeOf((*MockTaskRepoInter)(nil).GetCommentEdits), commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockTaskRepoInter) GetComments(taskID uint, page, limit int) ([]models.Comment, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", taskID, page, limit)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockTaskRepoInterMockRecorder) GetComments(taskID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockTaskRepoInter)(nil).GetComments), taskID, page, limit)
+}
+
+// GetDependentTaskIDs mocks base method.
+func (m *MockTaskRepoInter) GetDependentTaskIDs(taskID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDependentTaskIDs", taskID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDependentTaskIDs indicates an expected call of GetDependentTaskIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetDependentTaskIDs(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDependentTaskIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetDependentTaskIDs), taskID)
+}
+
+// GetDueTasks mocks base method.
+func (m *MockTaskRepoInter) GetDueTasks(from, to time.Time, excludeStatuses []models.TaskStatus) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueTasks", from, to, excludeStatuses)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueTasks indicates an expected call of GetDueTasks.
+func (mr *MockTaskRepoInterMockRecorder) GetDueTasks(from, to, excludeStatuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueTasks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetDueTasks), from, to, excludeStatuses)
+}
+
+// GetFilteredTasks mocks base method.
+func (m *MockTaskRepoInter) GetFilteredTasks(filter models.TaskFilter) ([]models.Task, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilteredTasks", filter)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFilteredTasks indicates an expected call of GetFilteredTasks.
+func (mr *MockTaskRepoInterMockRecorder) GetFilteredTasks(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredTasks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetFilteredTasks), filter)
+}
+
+// GetLabelByID mocks base method.
+func (m *MockTaskRepoInter) GetLabelByID(id uint) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelByID", id)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelByID indicates an expected call of GetLabelByID.
+func (mr *MockTaskRepoInterMockRecorder) GetLabelByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetLabelByID), id)
+}
+
+// GetLabelTaskIDs mocks base method.
+func (m *MockTaskRepoInter) GetLabelTaskIDs(labelID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelTaskIDs", labelID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelTaskIDs indicates an expected call of GetLabelTaskIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetLabelTaskIDs(labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelTaskIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetLabelTaskIDs), labelID)
+}
+
+// GetLastTaskHistory mocks base method.
+func (m *MockTaskRepoInter) GetLastTaskHistory(taskID uint) (*models.TaskHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastTaskHistory", taskID)
+	ret0, _ := ret[0].(*models.TaskHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastTaskHistory indicates an expected call of GetLastTaskHistory.
+func (mr *MockTaskRepoInterMockRecorder) GetLastTaskHistory(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastTaskHistory", reflect.TypeOf((*MockTaskRepoInter)(nil).GetLastTaskHistory), taskID)
+}
+
+// GetMutedUserIDs mocks base method.
+func (m *MockTaskRepoInter) GetMutedUserIDs(kind string, userIDs []uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMutedUserIDs", kind, userIDs)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMutedUserIDs indicates an expected call of GetMutedUserIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetMutedUserIDs(kind, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMutedUserIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetMutedUserIDs), kind, userIDs)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockTaskRepoInter) GetNotificationPreferences(userID uint) ([]models.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", userID)
+	ret0, _ := ret[0].([]models.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockTaskRepoInterMockRecorder) GetNotificationPreferences(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockTaskRepoInter)(nil).GetNotificationPreferences), userID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockTaskRepoInter) GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]models.Notification, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userID, unreadOnly, page, limit)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockTaskRepoInterMockRecorder) GetNotifications(userID, unreadOnly, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockTaskRepoInter)(nil).GetNotifications), userID, unreadOnly, page, limit)
+}
+
+// GetOpenBlockerIDs mocks base method.
+func (m *MockTaskRepoInter) GetOpenBlockerIDs(taskID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenBlockerIDs", taskID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenBlockerIDs indicates an expected call of GetOpenBlockerIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetOpenBlockerIDs(taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenBlockerIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetOpenBlockerIDs), taskID)
+}
+
+// GetProjectByID mocks base method.
+func (m *MockTaskRepoInter) GetProjectByID(id uint) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectByID", id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectByID indicates an expected call of GetProjectByID.
+func (mr *MockTaskRepoInterMockRecorder) GetProjectByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetProjectByID), id)
+}
+
+// GetSubtaskHeight mocks base method.
+func (m *MockTaskRepoInter) GetSubtaskHeight(id uint) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtaskHeight", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtaskHeight indicates an expected call of GetSubtaskHeight.
+func (mr *MockTaskRepoInterMockRecorder) GetSubtaskHeight(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtaskHeight", reflect.TypeOf((*MockTaskRepoInter)(nil).GetSubtaskHeight), id)
+}
+
+// GetSubtaskProgress mocks base method.
+func (m *MockTaskRepoInter) GetSubtaskProgress(taskIDs []uint) (map[uint]models.TaskProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtaskProgress", taskIDs)
+	ret0, _ := ret[0].(map[uint]models.TaskProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtaskProgress indicates an expected call of GetSubtaskProgress.
+func (mr *MockTaskRepoInterMockRecorder) GetSubtaskProgress(taskIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtaskProgress", reflect.TypeOf((*MockTaskRepoInter)(nil).GetSubtaskProgress), taskIDs)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTaskRepoInter) GetSubtasks(parentID uint) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", parentID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTaskRepoInterMockRecorder) GetSubtasks(parentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetSubtasks), parentID)
+}
+
+// GetTaskAncestorIDs mocks base method.
+func (m *MockTaskRepoInter) GetTaskAncestorIDs(id uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskAncestorIDs", id)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskAncestorIDs indicates an expected call of GetTaskAncestorIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetTaskAncestorIDs(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskAncestorIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTaskAncestorIDs), id)
+}
+
+// GetTaskByID mocks base method.
+func (m *MockTaskRepoInter) GetTaskByID(id uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskByID", id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskByID indicates an expected call of GetTaskByID.
+func (mr *MockTaskRepoInterMockRecorder) GetTaskByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTaskByID), id)
+}
+
+// GetTaskHistory mocks base method.
+func (m *MockTaskRepoInter) GetTaskHistory(taskID uint, page, limit int) ([]models.TaskHistory, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", taskID, page, limit)
+	ret0, _ := ret[0].([]models.TaskHistory)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockTaskRepoInterMockRecorder) GetTaskHistory(taskID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTaskHistory), taskID, page, limit)
+}
+
+// GetTeamMemberIDs mocks base method.
+func (m *MockTaskRepoInter) GetTeamMemberIDs(managerID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMemberIDs", managerID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMemberIDs indicates an expected call of GetTeamMemberIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetTeamMemberIDs(managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMemberIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTeamMemberIDs), managerID)
+}
+
+// GetTrashedTask mocks base method.
+func (m *MockTaskRepoInter) GetTrashedTask(id uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTask", id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrashedTask indicates an expected call of GetTrashedTask.
+func (mr *MockTaskRepoInterMockRecorder) GetTrashedTask(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTask", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTrashedTask), id)
+}
+
+// GetTrashedTasks mocks base method.
+func (m *MockTaskRepoInter) GetTrashedTasks(userID uint, page, limit int) ([]models.Task, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrashedTasks", userID, page, limit)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTrashedTasks indicates an expected call of GetTrashedTasks.
+func (mr *MockTaskRepoInterMockRecorder) GetTrashedTasks(userID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrashedTasks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetTrashedTasks), userID, page, limit)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockTaskRepoInter) GetUserByUsername(usename string) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", usename)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockTaskRepoInterMockRecorder) GetUserByUsername(usename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUserByUsername), usename)
+}
+
+// GetUserList mocks base method.
+func (m *MockTaskRepoInter) GetUserList(search string, page, limit int) ([]*models.Users, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserList", search, page, limit)
+	ret0, _ := ret[0].([]*models.Users)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserList indicates an expected call of GetUserList.
+func (mr *MockTaskRepoInterMockRecorder) GetUserList(search, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserList", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUserList), search, page, limit)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockTaskRepoInter) GetUsersByIDs(ids []uint) ([]models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", ids)
+	ret0, _ := ret[0].([]models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockTaskRepoInterMockRecorder) GetUsersByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUsersByIDs), ids)
+}
+
+// GetUsersByUsernames mocks base method.
+func (m *MockTaskRepoInter) GetUsersByUsernames(usernames []string) ([]models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByUsernames", usernames)
+	ret0, _ := ret[0].([]models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByUsernames indicates an expected call of GetUsersByUsernames.
+func (mr *MockTaskRepoInterMockRecorder) GetUsersByUsernames(usernames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByUsernames", reflect.TypeOf((*MockTaskRepoInter)(nil).GetUsersByUsernames), usernames)
+}
+
+// GetWebhookByID mocks base method.
+func (m *MockTaskRepoInter) GetWebhookByID(id uint) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookByID", id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookByID indicates an expected call of GetWebhookByID.
+func (mr *MockTaskRepoInterMockRecorder) GetWebhookByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetWebhookByID), id)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockTaskRepoInter) GetWebhookDeliveries(subscriptionID uint, page, limit int) ([]models.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", subscriptionID, page, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockTaskRepoInterMockRecorder) GetWebhookDeliveries(subscriptionID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockTaskRepoInter)(nil).GetWebhookDeliveries), subscriptionID, page, limit)
+}
+
+// GetWebhookDeliveryByID mocks base method.
+func (m *MockTaskRepoInter) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryByID", id)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryByID indicates an expected call of GetWebhookDeliveryByID.
+func (mr *MockTaskRepoInterMockRecorder) GetWebhookDeliveryByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryByID", reflect.TypeOf((*MockTaskRepoInter)(nil).GetWebhookDeliveryByID), id)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockTaskRepoInter) GetWebhooks(userID uint) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", userID)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockTaskRepoInterMockRecorder) GetWebhooks(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockTaskRepoInter)(nil).GetWebhooks), userID)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockTaskRepoInter) GetWorkflow() (*models.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow")
+	ret0, _ := ret[0].(*models.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockTaskRepoInterMockRecorder) GetWorkflow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockTaskRepoInter)(nil).GetWorkflow))
+}
+
+// IsProjectMember mocks base method.
+func (m *MockTaskRepoInter) IsProjectMember(projectID, userID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsProjectMember", projectID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsProjectMember indicates an expected call of IsProjectMember.
+func (mr *MockTaskRepoInterMockRecorder) IsProjectMember(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProjectMember", reflect.TypeOf((*MockTaskRepoInter)(nil).IsProjectMember), projectID, userID)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockTaskRepoInter) ListAPIKeys(userID uint) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockTaskRepoInterMockRecorder) ListAPIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockTaskRepoInter)(nil).ListAPIKeys), userID)
+}
+
+// ListLabels mocks base method.
+func (m *MockTaskRepoInter) ListLabels(userID uint) ([]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLabels", userID)
+	ret0, _ := ret[0].([]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLabels indicates an expected call of ListLabels.
+func (mr *MockTaskRepoInterMockRecorder) ListLabels(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLabels", reflect.TypeOf((*MockTaskRepoInter)(nil).ListLabels), userID)
+}
+
+// ListProjects mocks base method.
+func (m *MockTaskRepoInter) ListProjects(memberID uint, includeArchived bool) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", memberID, includeArchived)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockTaskRepoInterMockRecorder) ListProjects(memberID, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockTaskRepoInter)(nil).ListProjects), memberID, includeArchived)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockTaskRepoInter) MarkAllNotificationsRead(userID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockTaskRepoInterMockRecorder) MarkAllNotificationsRead(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockTaskRepoInter)(nil).MarkAllNotificationsRead), userID)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockTaskRepoInter) MarkNotificationRead(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockTaskRepoInterMockRecorder) MarkNotificationRead(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockTaskRepoInter)(nil).MarkNotificationRead), userID, id)
+}
+
+// PurgeTask mocks base method.
+func (m *MockTaskRepoInter) PurgeTask(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTask", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTask indicates an expected call of PurgeTask.
+func (mr *MockTaskRepoInterMockRecorder) PurgeTask(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTask", reflect.TypeOf((*MockTaskRepoInter)(nil).PurgeTask), userID, id)
+}
+
+// PurgeTrashedTasks mocks base method.
+func (m *MockTaskRepoInter) PurgeTrashedTasks(userID uint, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedTasks", userID, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedTasks indicates an expected call of PurgeTrashedTasks.
+func (mr *MockTaskRepoInterMockRecorder) PurgeTrashedTasks(userID, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedTasks", reflect.TypeOf((*MockTaskRepoInter)(nil).PurgeTrashedTasks), userID, deletedBefore)
+}
+
+// ReleaseReminder mocks base method.
+func (m *MockTaskRepoInter) ReleaseReminder(reminder *models.TaskReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReminder", reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseReminder indicates an expected call of ReleaseReminder.
+func (mr *MockTaskRepoInterMockRecorder) ReleaseReminder(reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReminder", reflect.TypeOf((*MockTaskRepoInter)(nil).ReleaseReminder), reminder)
+}
+
+// RemoveProjectMember mocks base method.
+func (m *MockTaskRepoInter) RemoveProjectMember(projectID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProjectMember", projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProjectMember indicates an expected call of RemoveProjectMember.
+func (mr *MockTaskRepoInterMockRecorder) RemoveProjectMember(projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProjectMember", reflect.TypeOf((*MockTaskRepoInter)(nil).RemoveProjectMember), projectID, userID)
+}
+
+// RemoveTaskDependency mocks base method.
+func (m *MockTaskRepoInter) RemoveTaskDependency(taskID, dependsOnID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskDependency", taskID, dependsOnID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskDependency indicates an expected call of RemoveTaskDependency.
+func (mr *MockTaskRepoInterMockRecorder) RemoveTaskDependency(taskID, dependsOnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskDependency", reflect.TypeOf((*MockTaskRepoInter)(nil).RemoveTaskDependency), taskID, dependsOnID)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockTaskRepoInter) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", userID, hashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockTaskRepoInterMockRecorder) ReplaceRecoveryCodes(userID, hashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockTaskRepoInter)(nil).ReplaceRecoveryCodes), userID, hashes)
+}
+
+// ReplaceWorkflow mocks base method.
+func (m *MockTaskRepoInter) ReplaceWorkflow(workflow *models.Workflow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceWorkflow", workflow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceWorkflow indicates an expected call of ReplaceWorkflow.
+func (mr *MockTaskRepoInterMockRecorder) ReplaceWorkflow(workflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceWorkflow", reflect.TypeOf((*MockTaskRepoInter)(nil).ReplaceWorkflow), workflow)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTaskRepoInter) RestoreTask(task *models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskRepoInterMockRecorder) RestoreTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTaskRepoInter)(nil).RestoreTask), task)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockTaskRepoInter) RevokeAPIKey(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockTaskRepoInterMockRecorder) RevokeAPIKey(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockTaskRepoInter)(nil).RevokeAPIKey), userID, id)
+}
+
+// SetNotificationPreferences mocks base method.
+func (m *MockTaskRepoInter) SetNotificationPreferences(userID uint, prefs map[string]bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNotificationPreferences", userID, prefs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNotificationPreferences indicates an expected call of SetNotificationPreferences.
+func (mr *MockTaskRepoInterMockRecorder) SetNotificationPreferences(userID, prefs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNotificationPreferences", reflect.TypeOf((*MockTaskRepoInter)(nil).SetNotificationPreferences), userID, prefs)
+}
+
+// StopTaskSeries mocks base method.
+func (m *MockTaskRepoInter) StopTaskSeries(seriesID uint) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTaskSeries", seriesID)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTaskSeries indicates an expected call of StopTaskSeries.
+func (mr *MockTaskRepoInterMockRecorder) StopTaskSeries(seriesID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTaskSeries", reflect.TypeOf((*MockTaskRepoInter)(nil).StopTaskSeries), seriesID)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockTaskRepoInter) TouchAPIKey(id uint, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockTaskRepoInterMockRecorder) TouchAPIKey(id, usedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockTaskRepoInter)(nil).TouchAPIKey), id, usedAt)
+}
+
+// UpdateComment mocks base method.
+func (m *MockTaskRepoInter) UpdateComment(comment *models.Comment, edit *models.CommentEdit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", comment, edit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockTaskRepoInterMockRecorder) UpdateComment(comment, edit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateComment), comment, edit)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockTaskRepoInter) UpdateLabel(label *models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockTaskRepoInterMockRecorder) UpdateLabel(label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateLabel), label)
+}
+
+// UpdateProject mocks base method.
+func (m *MockTaskRepoInter) UpdateProject(project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockTaskRepoInterMockRecorder) UpdateProject(project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateProject), project)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskRepoInter) UpdateTask(task *models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskRepoInterMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateTask), task)
+}
+
+// UpdateTaskAssignees mocks base method.
+func (m *MockTaskRepoInter) UpdateTaskAssignees(taskID uint, assign, unassign []uint, assignedBy uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaskAssignees", taskID, assign, unassign, assignedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTaskAssignees indicates an expected call of UpdateTaskAssignees.
+func (mr *MockTaskRepoInterMockRecorder) UpdateTaskAssignees(taskID, assign, unassign, assignedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskAssignees", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateTaskAssignees), taskID, assign, unassign, assignedBy)
+}
+
+// UpdateUserFlags mocks base method.
+func (m *MockTaskRepoInter) UpdateUserFlags(userID uint, fields map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserFlags", userID, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserFlags indicates an expected call of UpdateUserFlags.
+func (mr *MockTaskRepoInterMockRecorder) UpdateUserFlags(userID, fields interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserFlags", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateUserFlags), userID, fields)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockTaskRepoInter) UpdateUserRole(userID uint, role models.Role, managerID *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", userID, role, managerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockTaskRepoInterMockRecorder) UpdateUserRole(userID, role, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateUserRole), userID, role, managerID)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockTaskRepoInter) UpdateWebhook(sub *models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", sub)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockTaskRepoInterMockRecorder) UpdateWebhook(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateWebhook), sub)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockTaskRepoInter) UpdateWebhookDelivery(delivery *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockTaskRepoInterMockRecorder) UpdateWebhookDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockTaskRepoInter)(nil).UpdateWebhookDelivery), delivery)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTaskRepoInter) UseRecoveryCode(userID uint, hash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, hash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTaskRepoInterMockRecorder) UseRecoveryCode(userID, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTaskRepoInter)(nil).UseRecoveryCode), userID, hash)
 }
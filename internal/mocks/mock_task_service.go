@@ -5,10 +5,14 @@
 package mocks
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/ratheeshkumar25/task-mgt/internal/models"
+	utility "github.com/ratheeshkumar25/task-mgt/utility"
 )
 
 // MockTaskServiceInter is a mock of TaskServiceInter interface.
@@ -34,6 +38,169 @@ func (m *MockTaskServiceInter) EXPECT() *MockTaskServiceInterMockRecorder {
 	return m.recorder
 }
 
+// AddComment mocks base method.
+func (m *MockTaskServiceInter) AddComment(actor models.Actor, taskID uint, body string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddComment", actor, taskID, body)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddComment indicates an expected call of AddComment.
+func (mr *MockTaskServiceInterMockRecorder) AddComment(actor, taskID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddComment", reflect.TypeOf((*MockTaskServiceInter)(nil).AddComment), actor, taskID, body)
+}
+
+// AddDependency mocks base method.
+func (m *MockTaskServiceInter) AddDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDependency", actor, taskID, dependsOnID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDependency indicates an expected call of AddDependency.
+func (mr *MockTaskServiceInterMockRecorder) AddDependency(actor, taskID, dependsOnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDependency", reflect.TypeOf((*MockTaskServiceInter)(nil).AddDependency), actor, taskID, dependsOnID)
+}
+
+// AddProjectMember mocks base method.
+func (m *MockTaskServiceInter) AddProjectMember(actor models.Actor, projectID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddProjectMember", actor, projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddProjectMember indicates an expected call of AddProjectMember.
+func (mr *MockTaskServiceInterMockRecorder) AddProjectMember(actor, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProjectMember", reflect.TypeOf((*MockTaskServiceInter)(nil).AddProjectMember), actor, projectID, userID)
+}
+
+// AttachLabels mocks base method.
+func (m *MockTaskServiceInter) AttachLabels(actor models.Actor, taskID uint, labelIDs []uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachLabels", actor, taskID, labelIDs)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachLabels indicates an expected call of AttachLabels.
+func (mr *MockTaskServiceInterMockRecorder) AttachLabels(actor, taskID, labelIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachLabels", reflect.TypeOf((*MockTaskServiceInter)(nil).AttachLabels), actor, taskID, labelIDs)
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockTaskServiceInter) AuthenticateAPIKey(raw string) (*models.Users, *models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", raw)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(*models.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockTaskServiceInterMockRecorder) AuthenticateAPIKey(raw interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockTaskServiceInter)(nil).AuthenticateAPIKey), raw)
+}
+
+// BeginTOTPEnrollment mocks base method.
+func (m *MockTaskServiceInter) BeginTOTPEnrollment(userID uint) (*models.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", userID)
+	ret0, _ := ret[0].(*models.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockTaskServiceInterMockRecorder) BeginTOTPEnrollment(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockTaskServiceInter)(nil).BeginTOTPEnrollment), userID)
+}
+
+// CompleteMFALogin mocks base method.
+func (m *MockTaskServiceInter) CompleteMFALogin(mfaToken, code, clientIP string) (*utility.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMFALogin", mfaToken, code, clientIP)
+	ret0, _ := ret[0].(*utility.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMFALogin indicates an expected call of CompleteMFALogin.
+func (mr *MockTaskServiceInterMockRecorder) CompleteMFALogin(mfaToken, code, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMFALogin", reflect.TypeOf((*MockTaskServiceInter)(nil).CompleteMFALogin), mfaToken, code, clientIP)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockTaskServiceInter) ConfirmTOTPEnrollment(userID uint, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockTaskServiceInterMockRecorder) ConfirmTOTPEnrollment(userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockTaskServiceInter)(nil).ConfirmTOTPEnrollment), userID, code)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockTaskServiceInter) CreateAPIKey(userID uint, name string, scopes []string, ttl time.Duration) (*models.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", userID, name, scopes, ttl)
+	ret0, _ := ret[0].(*models.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockTaskServiceInterMockRecorder) CreateAPIKey(userID, name, scopes, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockTaskServiceInter)(nil).CreateAPIKey), userID, name, scopes, ttl)
+}
+
+// CreateLabel mocks base method.
+func (m *MockTaskServiceInter) CreateLabel(actor models.Actor, label *models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabel", actor, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLabel indicates an expected call of CreateLabel.
+func (mr *MockTaskServiceInterMockRecorder) CreateLabel(actor, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabel", reflect.TypeOf((*MockTaskServiceInter)(nil).CreateLabel), actor, label)
+}
+
+// CreateProject mocks base method.
+func (m *MockTaskServiceInter) CreateProject(actor models.Actor, project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", actor, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockTaskServiceInterMockRecorder) CreateProject(actor, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockTaskServiceInter)(nil).CreateProject), actor, project)
+}
+
 // CreateTask mocks base method.
 func (m *MockTaskServiceInter) CreateTask(task *models.Task) error {
 	m.ctrl.T.Helper()
@@ -62,24 +229,210 @@ func (mr *MockTaskServiceInterMockRecorder) CreateUser(user interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockTaskServiceInter)(nil).CreateUser), user)
 }
 
+// CreateWebhook mocks base method.
+func (m *MockTaskServiceInter) CreateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.CreatedWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", actor, sub)
+	ret0, _ := ret[0].(*models.CreatedWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockTaskServiceInterMockRecorder) CreateWebhook(actor, sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockTaskServiceInter)(nil).CreateWebhook), actor, sub)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockTaskServiceInter) DeleteAttachment(actor models.Actor, taskID, attachmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", actor, taskID, attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockTaskServiceInterMockRecorder) DeleteAttachment(actor, taskID, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteAttachment), actor, taskID, attachmentID)
+}
+
+// DeleteComment mocks base method.
+func (m *MockTaskServiceInter) DeleteComment(actor models.Actor, taskID, commentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", actor, taskID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockTaskServiceInterMockRecorder) DeleteComment(actor, taskID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteComment), actor, taskID, commentID)
+}
+
+// DeleteLabel mocks base method.
+func (m *MockTaskServiceInter) DeleteLabel(actor models.Actor, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabel", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabel indicates an expected call of DeleteLabel.
+func (mr *MockTaskServiceInterMockRecorder) DeleteLabel(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabel", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteLabel), actor, id)
+}
+
+// DeleteProject mocks base method.
+func (m *MockTaskServiceInter) DeleteProject(actor models.Actor, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProject", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProject indicates an expected call of DeleteProject.
+func (mr *MockTaskServiceInterMockRecorder) DeleteProject(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteProject), actor, id)
+}
+
 // DeleteTask mocks base method.
-func (m *MockTaskServiceInter) DeleteTask(id uint) error {
+func (m *MockTaskServiceInter) DeleteTask(actor models.Actor, id uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", id)
+	ret := m.ctrl.Call(m, "DeleteTask", actor, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskServiceInterMockRecorder) DeleteTask(id interface{}) *gomock.Call {
+func (mr *MockTaskServiceInterMockRecorder) DeleteTask(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteTask), actor, id)
+}
+
+// DeleteUser mocks base method.
+func (m *MockTaskServiceInter) DeleteUser(actor models.Actor, userID uint, reassignTo *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", actor, userID, reassignTo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockTaskServiceInterMockRecorder) DeleteUser(actor, userID, reassignTo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteUser), actor, userID, reassignTo)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockTaskServiceInter) DeleteWebhook(actor models.Actor, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockTaskServiceInterMockRecorder) DeleteWebhook(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteWebhook), actor, id)
+}
+
+// DeliverWebhooks mocks base method.
+func (m *MockTaskServiceInter) DeliverWebhooks(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverWebhooks", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverWebhooks indicates an expected call of DeliverWebhooks.
+func (mr *MockTaskServiceInterMockRecorder) DeliverWebhooks(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverWebhooks", reflect.TypeOf((*MockTaskServiceInter)(nil).DeliverWebhooks), ctx)
+}
+
+// DetachLabel mocks base method.
+func (m *MockTaskServiceInter) DetachLabel(actor models.Actor, taskID, labelID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachLabel", actor, taskID, labelID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DetachLabel indicates an expected call of DetachLabel.
+func (mr *MockTaskServiceInterMockRecorder) DetachLabel(actor, taskID, labelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachLabel", reflect.TypeOf((*MockTaskServiceInter)(nil).DetachLabel), actor, taskID, labelID)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockTaskServiceInter) DisableTOTP(userID uint, password, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", userID, password, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockTaskServiceInterMockRecorder) DisableTOTP(userID, password, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockTaskServiceInter)(nil).DisableTOTP), userID, password, code)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockTaskServiceInter) EmptyTrash(actor models.Actor) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", actor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockTaskServiceInterMockRecorder) EmptyTrash(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockTaskServiceInter)(nil).EmptyTrash), actor)
+}
+
+// ForcePasswordReset mocks base method.
+func (m *MockTaskServiceInter) ForcePasswordReset(actor models.Actor, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForcePasswordReset", actor, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForcePasswordReset indicates an expected call of ForcePasswordReset.
+func (mr *MockTaskServiceInterMockRecorder) ForcePasswordReset(actor, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForcePasswordReset", reflect.TypeOf((*MockTaskServiceInter)(nil).ForcePasswordReset), actor, userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockTaskServiceInter) ForgotPassword(username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockTaskServiceInterMockRecorder) ForgotPassword(username interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskServiceInter)(nil).DeleteTask), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockTaskServiceInter)(nil).ForgotPassword), username)
 }
 
 // GetAllTasks mocks base method.
-func (m *MockTaskServiceInter) GetAllTasks(status, dueDateAfter, sortBy, sortOrder string, page, limit int) ([]models.Task, int64, error) {
+func (m *MockTaskServiceInter) GetAllTasks(actor models.Actor, filter models.TaskFilter) ([]models.Task, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTasks", status, dueDateAfter, sortBy, sortOrder, page, limit)
+	ret := m.ctrl.Call(m, "GetAllTasks", actor, filter)
 	ret0, _ := ret[0].([]models.Task)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -87,51 +440,811 @@ func (m *MockTaskServiceInter) GetAllTasks(status, dueDateAfter, sortBy, sortOrd
 }
 
 // GetAllTasks indicates an expected call of GetAllTasks.
-func (mr *MockTaskServiceInterMockRecorder) GetAllTasks(status, dueDateAfter, sortBy, sortOrder, page, limit interface{}) *gomock.Call {
+func (mr *MockTaskServiceInterMockRecorder) GetAllTasks(actor, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTaskServiceInter)(nil).GetAllTasks), actor, filter)
+}
+
+// GetCommentEdits mocks base method.
+func (m *MockTaskServiceInter) GetCommentEdits(actor models.Actor, taskID, commentID uint) ([]models.CommentEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentEdits", actor, taskID, commentID)
+	ret0, _ := ret[0].([]models.CommentEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentEdits indicates an expected call of GetCommentEdits.
+func (mr *MockTaskServiceInterMockRecorder) GetCommentEdits(actor, taskID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentEdits", reflect.TypeOf((*MockTaskServiceInter)(nil).GetCommentEdits), actor, taskID, commentID)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockTaskServiceInter) GetNotificationPreferences(actor models.Actor) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", actor)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockTaskServiceInterMockRecorder) GetNotificationPreferences(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockTaskServiceInter)(nil).GetNotificationPreferences), actor)
+}
+
+// GetProject mocks base method.
+func (m *MockTaskServiceInter) GetProject(actor models.Actor, id uint) (*models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProject", actor, id)
+	ret0, _ := ret[0].(*models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProject indicates an expected call of GetProject.
+func (mr *MockTaskServiceInterMockRecorder) GetProject(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProject", reflect.TypeOf((*MockTaskServiceInter)(nil).GetProject), actor, id)
+}
+
+// GetProjectTasks mocks base method.
+func (m *MockTaskServiceInter) GetProjectTasks(actor models.Actor, projectID uint, filter models.TaskFilter) ([]models.Task, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectTasks", actor, projectID, filter)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetProjectTasks indicates an expected call of GetProjectTasks.
+func (mr *MockTaskServiceInterMockRecorder) GetProjectTasks(actor, projectID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectTasks", reflect.TypeOf((*MockTaskServiceInter)(nil).GetProjectTasks), actor, projectID, filter)
+}
+
+// GetSubtasks mocks base method.
+func (m *MockTaskServiceInter) GetSubtasks(actor models.Actor, id uint) ([]models.Task, *models.TaskProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasks", actor, id)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(*models.TaskProgress)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSubtasks indicates an expected call of GetSubtasks.
+func (mr *MockTaskServiceInterMockRecorder) GetSubtasks(actor, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTasks", reflect.TypeOf((*MockTaskServiceInter)(nil).GetAllTasks), status, dueDateAfter, sortBy, sortOrder, page, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasks", reflect.TypeOf((*MockTaskServiceInter)(nil).GetSubtasks), actor, id)
 }
 
 // GetTaskByID mocks base method.
-func (m *MockTaskServiceInter) GetTaskByID(id uint) (*models.Task, error) {
+func (m *MockTaskServiceInter) GetTaskByID(actor models.Actor, id uint) (*models.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskByID", id)
+	ret := m.ctrl.Call(m, "GetTaskByID", actor, id)
 	ret0, _ := ret[0].(*models.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskByID indicates an expected call of GetTaskByID.
-func (mr *MockTaskServiceInterMockRecorder) GetTaskByID(id interface{}) *gomock.Call {
+func (mr *MockTaskServiceInterMockRecorder) GetTaskByID(actor, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskServiceInter)(nil).GetTaskByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockTaskServiceInter)(nil).GetTaskByID), actor, id)
 }
 
-// LoginUser mocks base method.
-func (m *MockTaskServiceInter) LoginUser(username, password string) (string, error) {
+// GetTaskHistory mocks base method.
+func (m *MockTaskServiceInter) GetTaskHistory(actor models.Actor, id uint, page, limit int) ([]models.TaskHistory, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskHistory", actor, id, page, limit)
+	ret0, _ := ret[0].([]models.TaskHistory)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTaskHistory indicates an expected call of GetTaskHistory.
+func (mr *MockTaskServiceInterMockRecorder) GetTaskHistory(actor, id, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskHistory", reflect.TypeOf((*MockTaskServiceInter)(nil).GetTaskHistory), actor, id, page, limit)
+}
+
+// GetTaskTransitions mocks base method.
+func (m *MockTaskServiceInter) GetTaskTransitions(actor models.Actor, id uint) (*models.TaskTransitions, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", username, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GetTaskTransitions", actor, id)
+	ret0, _ := ret[0].(*models.TaskTransitions)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LoginUser indicates an expected call of LoginUser.
-func (mr *MockTaskServiceInterMockRecorder) LoginUser(username, password interface{}) *gomock.Call {
+// GetTaskTransitions indicates an expected call of GetTaskTransitions.
+func (mr *MockTaskServiceInterMockRecorder) GetTaskTransitions(actor, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockTaskServiceInter)(nil).LoginUser), username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskTransitions", reflect.TypeOf((*MockTaskServiceInter)(nil).GetTaskTransitions), actor, id)
 }
 
-// UpdateTask mocks base method.
-func (m *MockTaskServiceInter) UpdateTask(task *models.Task) error {
+// GetTrash mocks base method.
+func (m *MockTaskServiceInter) GetTrash(actor models.Actor, page, limit int) ([]models.TrashedTask, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", task)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetTrash", actor, page, limit)
+	ret0, _ := ret[0].([]models.TrashedTask)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskServiceInterMockRecorder) UpdateTask(task interface{}) *gomock.Call {
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTaskServiceInterMockRecorder) GetTrash(actor, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTaskServiceInter)(nil).GetTrash), actor, page, limit)
+}
+
+// GetUserDetail mocks base method.
+func (m *MockTaskServiceInter) GetUserDetail(actor models.Actor, userID uint) (*models.UserDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserDetail", actor, userID)
+	ret0, _ := ret[0].(*models.UserDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserDetail indicates an expected call of GetUserDetail.
+func (mr *MockTaskServiceInterMockRecorder) GetUserDetail(actor, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDetail", reflect.TypeOf((*MockTaskServiceInter)(nil).GetUserDetail), actor, userID)
+}
+
+// GetWebhook mocks base method.
+func (m *MockTaskServiceInter) GetWebhook(actor models.Actor, id uint) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", actor, id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockTaskServiceInterMockRecorder) GetWebhook(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockTaskServiceInter)(nil).GetWebhook), actor, id)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockTaskServiceInter) GetWorkflow() (*models.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow")
+	ret0, _ := ret[0].(*models.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockTaskServiceInterMockRecorder) GetWorkflow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockTaskServiceInter)(nil).GetWorkflow))
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockTaskServiceInter) ListAPIKeys(userID uint) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockTaskServiceInterMockRecorder) ListAPIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockTaskServiceInter)(nil).ListAPIKeys), userID)
+}
+
+// ListAttachments mocks base method.
+func (m *MockTaskServiceInter) ListAttachments(actor models.Actor, taskID uint) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttachments", actor, taskID)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttachments indicates an expected call of ListAttachments.
+func (mr *MockTaskServiceInterMockRecorder) ListAttachments(actor, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttachments", reflect.TypeOf((*MockTaskServiceInter)(nil).ListAttachments), actor, taskID)
+}
+
+// ListComments mocks base method.
+func (m *MockTaskServiceInter) ListComments(actor models.Actor, taskID uint, page, limit int) ([]models.Comment, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComments", actor, taskID, page, limit)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListComments indicates an expected call of ListComments.
+func (mr *MockTaskServiceInterMockRecorder) ListComments(actor, taskID, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComments", reflect.TypeOf((*MockTaskServiceInter)(nil).ListComments), actor, taskID, page, limit)
+}
+
+// ListLabels mocks base method.
+func (m *MockTaskServiceInter) ListLabels(actor models.Actor) ([]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLabels", actor)
+	ret0, _ := ret[0].([]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLabels indicates an expected call of ListLabels.
+func (mr *MockTaskServiceInterMockRecorder) ListLabels(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLabels", reflect.TypeOf((*MockTaskServiceInter)(nil).ListLabels), actor)
+}
+
+// ListNotifications mocks base method.
+func (m *MockTaskServiceInter) ListNotifications(actor models.Actor, unreadOnly bool, page, limit int) ([]models.Notification, int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", actor, unreadOnly, page, limit)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(int64)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockTaskServiceInterMockRecorder) ListNotifications(actor, unreadOnly, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockTaskServiceInter)(nil).ListNotifications), actor, unreadOnly, page, limit)
+}
+
+// ListProjects mocks base method.
+func (m *MockTaskServiceInter) ListProjects(actor models.Actor, includeArchived bool) ([]models.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProjects", actor, includeArchived)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProjects indicates an expected call of ListProjects.
+func (mr *MockTaskServiceInterMockRecorder) ListProjects(actor, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProjects", reflect.TypeOf((*MockTaskServiceInter)(nil).ListProjects), actor, includeArchived)
+}
+
+// ListUsers mocks base method.
+func (m *MockTaskServiceInter) ListUsers(actor models.Actor, search string, page, limit int) ([]models.UserResponse, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", actor, search, page, limit)
+	ret0, _ := ret[0].([]models.UserResponse)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockTaskServiceInterMockRecorder) ListUsers(actor, search, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockTaskServiceInter)(nil).ListUsers), actor, search, page, limit)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockTaskServiceInter) ListWebhookDeliveries(actor models.Actor, id uint, page, limit int) ([]models.WebhookDelivery, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", actor, id, page, limit)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockTaskServiceInterMockRecorder) ListWebhookDeliveries(actor, id, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockTaskServiceInter)(nil).ListWebhookDeliveries), actor, id, page, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockTaskServiceInter) ListWebhooks(actor models.Actor) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", actor)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockTaskServiceInterMockRecorder) ListWebhooks(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockTaskServiceInter)(nil).ListWebhooks), actor)
+}
+
+// LoginUser mocks base method.
+func (m *MockTaskServiceInter) LoginUser(username, password, clientIP string) (*utility.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", username, password, clientIP)
+	ret0, _ := ret[0].(*utility.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockTaskServiceInterMockRecorder) LoginUser(username, password, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockTaskServiceInter)(nil).LoginUser), username, password, clientIP)
+}
+
+// Logout mocks base method.
+func (m *MockTaskServiceInter) Logout(claims *utility.UserClaim) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockTaskServiceInterMockRecorder) Logout(claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockTaskServiceInter)(nil).Logout), claims)
+}
+
+// LogoutAll mocks base method.
+func (m *MockTaskServiceInter) LogoutAll(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockTaskServiceInterMockRecorder) LogoutAll(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockTaskServiceInter)(nil).LogoutAll), userID)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockTaskServiceInter) MarkAllNotificationsRead(actor models.Actor) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", actor)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockTaskServiceInterMockRecorder) MarkAllNotificationsRead(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockTaskServiceInter)(nil).MarkAllNotificationsRead), actor)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockTaskServiceInter) MarkNotificationRead(actor models.Actor, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockTaskServiceInterMockRecorder) MarkNotificationRead(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockTaskServiceInter)(nil).MarkNotificationRead), actor, id)
+}
+
+// OpenAttachment mocks base method.
+func (m *MockTaskServiceInter) OpenAttachment(actor models.Actor, taskID, attachmentID uint) (*models.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAttachment", actor, taskID, attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenAttachment indicates an expected call of OpenAttachment.
+func (mr *MockTaskServiceInterMockRecorder) OpenAttachment(actor, taskID, attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAttachment", reflect.TypeOf((*MockTaskServiceInter)(nil).OpenAttachment), actor, taskID, attachmentID)
+}
+
+// PreviewRecurrence mocks base method.
+func (m *MockTaskServiceInter) PreviewRecurrence(actor models.Actor, id uint, n int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewRecurrence", actor, id, n)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRecurrence indicates an expected call of PreviewRecurrence.
+func (mr *MockTaskServiceInterMockRecorder) PreviewRecurrence(actor, id, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRecurrence", reflect.TypeOf((*MockTaskServiceInter)(nil).PreviewRecurrence), actor, id, n)
+}
+
+// PurgeExpiredTrash mocks base method.
+func (m *MockTaskServiceInter) PurgeExpiredTrash() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredTrash")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredTrash indicates an expected call of PurgeExpiredTrash.
+func (mr *MockTaskServiceInterMockRecorder) PurgeExpiredTrash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredTrash", reflect.TypeOf((*MockTaskServiceInter)(nil).PurgeExpiredTrash))
+}
+
+// PurgeTask mocks base method.
+func (m *MockTaskServiceInter) PurgeTask(actor models.Actor, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTask", actor, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeTask indicates an expected call of PurgeTask.
+func (mr *MockTaskServiceInterMockRecorder) PurgeTask(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTask", reflect.TypeOf((*MockTaskServiceInter)(nil).PurgeTask), actor, id)
+}
+
+// RedeliverWebhook mocks base method.
+func (m *MockTaskServiceInter) RedeliverWebhook(actor models.Actor, id, deliveryID uint) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhook", actor, id, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhook indicates an expected call of RedeliverWebhook.
+func (mr *MockTaskServiceInterMockRecorder) RedeliverWebhook(actor, id, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhook", reflect.TypeOf((*MockTaskServiceInter)(nil).RedeliverWebhook), actor, id, deliveryID)
+}
+
+// RefreshToken mocks base method.
+func (m *MockTaskServiceInter) RefreshToken(refreshToken string) (*utility.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", refreshToken)
+	ret0, _ := ret[0].(*utility.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockTaskServiceInterMockRecorder) RefreshToken(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockTaskServiceInter)(nil).RefreshToken), refreshToken)
+}
+
+// RemoveDependency mocks base method.
+func (m *MockTaskServiceInter) RemoveDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveDependency", actor, taskID, dependsOnID)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveDependency indicates an expected call of RemoveDependency.
+func (mr *MockTaskServiceInterMockRecorder) RemoveDependency(actor, taskID, dependsOnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDependency", reflect.TypeOf((*MockTaskServiceInter)(nil).RemoveDependency), actor, taskID, dependsOnID)
+}
+
+// RemoveProjectMember mocks base method.
+func (m *MockTaskServiceInter) RemoveProjectMember(actor models.Actor, projectID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveProjectMember", actor, projectID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveProjectMember indicates an expected call of RemoveProjectMember.
+func (mr *MockTaskServiceInterMockRecorder) RemoveProjectMember(actor, projectID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProjectMember", reflect.TypeOf((*MockTaskServiceInter)(nil).RemoveProjectMember), actor, projectID, userID)
+}
+
+// ResendVerificationEmail mocks base method.
+func (m *MockTaskServiceInter) ResendVerificationEmail(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerificationEmail", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerificationEmail indicates an expected call of ResendVerificationEmail.
+func (mr *MockTaskServiceInterMockRecorder) ResendVerificationEmail(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerificationEmail", reflect.TypeOf((*MockTaskServiceInter)(nil).ResendVerificationEmail), userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockTaskServiceInter) ResetPassword(token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockTaskServiceInterMockRecorder) ResetPassword(token, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockTaskServiceInter)(nil).ResetPassword), token, newPassword)
+}
+
+// RestoreTask mocks base method.
+func (m *MockTaskServiceInter) RestoreTask(actor models.Actor, id uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreTask", actor, id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreTask indicates an expected call of RestoreTask.
+func (mr *MockTaskServiceInterMockRecorder) RestoreTask(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTask", reflect.TypeOf((*MockTaskServiceInter)(nil).RestoreTask), actor, id)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockTaskServiceInter) RevokeAPIKey(userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockTaskServiceInterMockRecorder) RevokeAPIKey(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockTaskServiceInter)(nil).RevokeAPIKey), userID, id)
+}
+
+// SendDueReminders mocks base method.
+func (m *MockTaskServiceInter) SendDueReminders(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendDueReminders", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendDueReminders indicates an expected call of SendDueReminders.
+func (mr *MockTaskServiceInterMockRecorder) SendDueReminders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDueReminders", reflect.TypeOf((*MockTaskServiceInter)(nil).SendDueReminders), ctx)
+}
+
+// SetUserDisabled mocks base method.
+func (m *MockTaskServiceInter) SetUserDisabled(actor models.Actor, userID uint, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserDisabled", actor, userID, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockTaskServiceInterMockRecorder) SetUserDisabled(actor, userID, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockTaskServiceInter)(nil).SetUserDisabled), actor, userID, disabled)
+}
+
+// StopRecurrence mocks base method.
+func (m *MockTaskServiceInter) StopRecurrence(actor models.Actor, id uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopRecurrence", actor, id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopRecurrence indicates an expected call of StopRecurrence.
+func (mr *MockTaskServiceInterMockRecorder) StopRecurrence(actor, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopRecurrence", reflect.TypeOf((*MockTaskServiceInter)(nil).StopRecurrence), actor, id)
+}
+
+// UnlockUser mocks base method.
+func (m *MockTaskServiceInter) UnlockUser(actor models.Actor, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", actor, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockTaskServiceInterMockRecorder) UnlockUser(actor, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockTaskServiceInter)(nil).UnlockUser), actor, userID)
+}
+
+// UpdateAssignees mocks base method.
+func (m *MockTaskServiceInter) UpdateAssignees(actor models.Actor, taskID uint, assign, unassign []uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAssignees", actor, taskID, assign, unassign)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAssignees indicates an expected call of UpdateAssignees.
+func (mr *MockTaskServiceInterMockRecorder) UpdateAssignees(actor, taskID, assign, unassign interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAssignees", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateAssignees), actor, taskID, assign, unassign)
+}
+
+// UpdateComment mocks base method.
+func (m *MockTaskServiceInter) UpdateComment(actor models.Actor, taskID, commentID uint, body string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", actor, taskID, commentID, body)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockTaskServiceInterMockRecorder) UpdateComment(actor, taskID, commentID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateComment), actor, taskID, commentID, body)
+}
+
+// UpdateLabel mocks base method.
+func (m *MockTaskServiceInter) UpdateLabel(actor models.Actor, label *models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabel", actor, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLabel indicates an expected call of UpdateLabel.
+func (mr *MockTaskServiceInterMockRecorder) UpdateLabel(actor, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabel", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateLabel), actor, label)
+}
+
+// UpdateNotificationPreferences mocks base method.
+func (m *MockTaskServiceInter) UpdateNotificationPreferences(actor models.Actor, prefs map[string]bool) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationPreferences", actor, prefs)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationPreferences indicates an expected call of UpdateNotificationPreferences.
+func (mr *MockTaskServiceInterMockRecorder) UpdateNotificationPreferences(actor, prefs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreferences", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateNotificationPreferences), actor, prefs)
+}
+
+// UpdateProject mocks base method.
+func (m *MockTaskServiceInter) UpdateProject(actor models.Actor, project *models.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", actor, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockTaskServiceInterMockRecorder) UpdateProject(actor, project interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateProject), actor, project)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskServiceInter) UpdateTask(actor models.Actor, task *models.Task, force bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", actor, task, force)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskServiceInterMockRecorder) UpdateTask(actor, task, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateTask), actor, task, force)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockTaskServiceInter) UpdateUserRole(actor models.Actor, userID uint, role models.Role, managerID *uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", actor, userID, role, managerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockTaskServiceInterMockRecorder) UpdateUserRole(actor, userID, role, managerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateUserRole), actor, userID, role, managerID)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockTaskServiceInter) UpdateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", actor, sub)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockTaskServiceInterMockRecorder) UpdateWebhook(actor, sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateWebhook), actor, sub)
+}
+
+// UpdateWorkflow mocks base method.
+func (m *MockTaskServiceInter) UpdateWorkflow(actor models.Actor, workflow *models.Workflow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkflow", actor, workflow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkflow indicates an expected call of UpdateWorkflow.
+func (mr *MockTaskServiceInterMockRecorder) UpdateWorkflow(actor, workflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflow", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateWorkflow), actor, workflow)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockTaskServiceInter) UploadAttachment(actor models.Actor, taskID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", actor, taskID, fileName, size, r)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockTaskServiceInterMockRecorder) UploadAttachment(actor, taskID, fileName, size, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockTaskServiceInter)(nil).UploadAttachment), actor, taskID, fileName, size, r)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockTaskServiceInter) VerifyEmail(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockTaskServiceInterMockRecorder) VerifyEmail(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockTaskServiceInter)(nil).VerifyEmail), token)
+}
+
+// VerifyTokenUser mocks base method.
+func (m *MockTaskServiceInter) VerifyTokenUser(claims *utility.UserClaim) (*models.Users, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTokenUser", claims)
+	ret0, _ := ret[0].(*models.Users)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTokenUser indicates an expected call of VerifyTokenUser.
+func (mr *MockTaskServiceInterMockRecorder) VerifyTokenUser(claims interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskServiceInter)(nil).UpdateTask), task)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTokenUser", reflect.TypeOf((*MockTaskServiceInter)(nil).VerifyTokenUser), claims)
 }
@@ -0,0 +1,50 @@
+package models
+
+import "errors"
+
+// ErrTaskNotFound is returned when a task does not exist or is not visible
+// to the requesting user. Callers should map it to 404 so that task IDs
+// belonging to other users are indistinguishable from missing ones.
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrUserNotFound is returned when a user lookup matches no row.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrForbidden is returned when the actor's role does not allow an action.
+var ErrForbidden = errors.New("permission denied")
+
+// ErrAPIKeyNotFound is returned when an API key does not exist or belongs to another user.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// ErrProjectNotFound is returned when a project does not exist or the actor is not a member.
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrLabelNotFound is returned when a label does not exist or belongs to another user.
+var ErrLabelNotFound = errors.New("label not found")
+
+// ErrDependencyNotFound is returned when a task does not depend on the given task.
+var ErrDependencyNotFound = errors.New("dependency not found")
+
+// ErrDependencyCycle is returned when a new dependency would make a task wait on itself.
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// ErrCommentNotFound is returned when a comment does not exist on the given task.
+var ErrCommentNotFound = errors.New("comment not found")
+
+// ErrAttachmentNotFound is returned when an attachment does not exist on the given task.
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// ErrStorageQuotaExceeded is returned when an upload would take a user over their storage quota.
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// ErrNotificationNotFound is returned when a notification does not exist in the user's inbox.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// ErrWebhookNotFound is returned when a webhook subscription does not exist or is not the caller's.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrWebhookDeliveryNotFound is returned when a delivery does not exist in a subscription's log.
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+// ErrStatusInUse is returned when a workflow change drops a status tasks are still in.
+var ErrStatusInUse = errors.New("status is still used by tasks")
@@ -1,31 +1,488 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"strings"
+	"time"
 
+	"gorm.io/gorm"
+)
+
+// TaskStatus is the name of a WorkflowStatus
 type TaskStatus string
 
+// Statuses of the default workflow
 const (
 	TaskStatusPending    TaskStatus = "Pending"
 	TaskStatusInProgress TaskStatus = "In Progress"
 	TaskStatusCompleted  TaskStatus = "Completed"
 )
 
+type TaskPriority string
+
+const (
+	TaskPriorityLow    TaskPriority = "low"
+	TaskPriorityMedium TaskPriority = "medium"
+	TaskPriorityHigh   TaskPriority = "high"
+	TaskPriorityUrgent TaskPriority = "urgent"
+)
+
+// Task list sort fields accepted in TaskFilter.SortBy. SortSmart lists overdue
+// tasks first, then by priority, then by due date.
+const (
+	SortSmart = "smart"
+)
+
+var taskSortFields = map[string]bool{
+	"due_date":   true,
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+	"status":     true,
+	"priority":   true,
+	SortSmart:    true,
+}
+
+type Role string
+
+const (
+	RoleUser    Role = "user"
+	RoleManager Role = "manager"
+	RoleAdmin   Role = "admin"
+)
+
 type Users struct {
-	ID           uint      `json:"id"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password"`
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"password"`
+	Role         Role   `json:"role" gorm:"type:varchar(20);not null;default:user"`
+	ManagerID    *uint  `json:"manager_id,omitempty" gorm:"index"`
+	// Disabled accounts can neither log in nor use existing tokens
+	Disabled bool `json:"disabled" gorm:"not null;default:false"`
+	// MustResetPassword blocks login until the password has been reset
+	MustResetPassword bool       `json:"must_reset_password" gorm:"not null;default:false"`
+	EmailVerified     bool       `json:"email_verified" gorm:"not null;default:false"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at,omitempty"`
+	// TOTPSecret is set while enrolling and kept once TOTPEnabled
+	TOTPSecret  string `json:"-"`
+	TOTPEnabled bool   `json:"totp_enabled" gorm:"not null;default:false"`
+	// TOTPLastStep is the last accepted time step, codes are never accepted twice
+	TOTPLastStep int64     `json:"-"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// RecoveryCode is a one-time code that replaces a TOTP code when the device is lost
+type RecoveryCode struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"-" gorm:"index;not null"`
+	CodeHash  string     `json:"-" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// API key scopes
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+)
+
+// APIKey is a personal key for scripts and CI. Only a hash of the key is stored,
+// Prefix identifies the key in listings and lookups.
+type APIKey struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"-" gorm:"index;not null"`
+	Name       string     `json:"name" gorm:"not null"`
+	Prefix     string     `json:"prefix" gorm:"uniqueIndex;not null"`
+	KeyHash    string     `json:"-" gorm:"not null"`
+	Scopes     string     `json:"scopes" gorm:"not null"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ScopeList splits the stored scopes
+func (k *APIKey) ScopeList() []string {
+	if k.Scopes == "" {
+		return nil
+	}
+	return strings.Split(k.Scopes, ",")
+}
+
+// CreatedAPIKey is returned once, when the key is minted
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// IsValidScope checks if the API key scope is valid
+func IsValidScope(scope string) bool {
+	return scope == ScopeTasksRead || scope == ScopeTasksWrite
+}
+
+// TOTPEnrollment is handed out once when 2FA enrollment starts
+type TOTPEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// UserResponse is the admin view of a user, without the password hash
+type UserResponse struct {
+	ID                uint      `json:"id"`
+	Username          string    `json:"username"`
+	Role              Role      `json:"role"`
+	ManagerID         *uint     `json:"manager_id,omitempty"`
+	Disabled          bool      `json:"disabled"`
+	MustResetPassword bool      `json:"must_reset_password"`
+	EmailVerified     bool      `json:"email_verified"`
+	TOTPEnabled       bool      `json:"totp_enabled"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// UserDetail is a user together with the number of tasks they own per status
+type UserDetail struct {
+	UserResponse
+	TaskCounts map[TaskStatus]int64 `json:"task_counts"`
+	TotalTasks int64                `json:"total_tasks"`
+}
+
+// ToResponse strips the credentials off a user
+func (u *Users) ToResponse() UserResponse {
+	return UserResponse{
+		ID:                u.ID,
+		Username:          u.Username,
+		Role:              u.Role,
+		ManagerID:         u.ManagerID,
+		Disabled:          u.Disabled,
+		MustResetPassword: u.MustResetPassword,
+		EmailVerified:     u.EmailVerified,
+		TOTPEnabled:       u.TOTPEnabled,
+		CreatedAt:         u.CreatedAt,
+	}
+}
+
+// Actor is the authenticated user a service call is made on behalf of
+type Actor struct {
+	UserID uint
+	Role   Role
+}
+
 type Task struct {
-	ID          uint       `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      TaskStatus `json:"status"`
-	DueDate     *time.Time `json:"dueDate,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	UserID      uint       `json:"-"`
+	ID          uint         `json:"id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status"`
+	Priority    TaskPriority `json:"priority" gorm:"type:varchar(10);not null;default:medium;index"`
+	DueDate     *time.Time   `json:"dueDate,omitempty"`
+	// ReminderMinutes are the reminders before DueDate in minutes, empty for the
+	// server's default reminders
+	ReminderMinutes []int `json:"reminderMinutes,omitempty" gorm:"serializer:json;type:jsonb"`
+	// Recurrence is an RRULE; completing the task creates the next occurrence
+	Recurrence string `json:"recurrence,omitempty"`
+	// SeriesID is the first task of a recurring series, nil on that task itself.
+	// Occurrence numbers the tasks of a series from 1.
+	SeriesID   *uint     `json:"seriesId,omitempty" gorm:"uniqueIndex:idx_task_series_occurrence"`
+	Occurrence int       `json:"occurrence,omitempty" gorm:"uniqueIndex:idx_task_series_occurrence"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	// UserID is the creator, who owns the task
+	UserID uint `json:"creatorId"`
+	// ProjectID puts the task on a project board, only its members can see it
+	ProjectID *uint `json:"projectId,omitempty" gorm:"index"`
+	// AssigneeIDs are the users working on the task, loaded from TaskAssignee
+	AssigneeIDs []uint `json:"assigneeIds" gorm:"-"`
+	// Labels are loaded from TaskLabel
+	Labels []Label `json:"labels" gorm:"-"`
+	// ParentID makes the task a subtask. Parent and subtask share the project, or
+	// the owner for personal tasks.
+	ParentID *uint `json:"parentId,omitempty" gorm:"index"`
+	// Progress rolls up the subtasks at every depth, nil for tasks without any
+	Progress *TaskProgress `json:"progress,omitempty" gorm:"-"`
+	// DependsOn are the tasks that have to be completed first, loaded from TaskDependency
+	DependsOn []uint `json:"dependsOn" gorm:"-"`
+	// Blocked is set while any task in DependsOn is not completed
+	Blocked bool `json:"blocked" gorm:"-"`
+	// CommentCount is the number of comments in the task's thread
+	CommentCount int64 `json:"commentCount" gorm:"-"`
+	// DeletedAt puts the task in its owner's trash, queries skip trashed tasks
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// TrashedTask is a task in its owner's trash. PurgeAt is when the retention job
+// deletes it for good, nil when the trash is only emptied by hand.
+type TrashedTask struct {
+	Task
+	DeletedAt time.Time  `json:"deletedAt"`
+	PurgeAt   *time.Time `json:"purgeAt,omitempty"`
+}
+
+// TaskDependency is an edge of the dependency graph: TaskID is blocked by DependsOnID
+type TaskDependency struct {
+	TaskID      uint      `json:"task_id" gorm:"primaryKey"`
+	DependsOnID uint      `json:"depends_on_id" gorm:"primaryKey;index"`
+	CreatedBy   uint      `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// History actions of TaskHistory.Action
+const (
+	HistoryCreated  = "created"
+	HistoryUpdated  = "updated"
+	HistoryDeleted  = "deleted"
+	HistoryRestored = "restored"
+)
+
+// TaskHistory is an immutable audit entry of a change to a task. OwnerID and
+// ProjectID are copied from the task so access can still be checked once it is deleted.
+type TaskHistory struct {
+	ID        uint          `json:"id"`
+	TaskID    uint          `json:"task_id" gorm:"index;not null"`
+	ActorID   uint          `json:"actor_id"`
+	Action    string        `json:"action" gorm:"type:varchar(10);not null"`
+	Changes   []FieldChange `json:"changes" gorm:"serializer:json;type:jsonb"`
+	OwnerID   uint          `json:"-"`
+	ProjectID *uint         `json:"-"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// Comment is a Markdown message in the discussion thread of a task. Only its author
+// edits it, EditedAt is set once they have.
+type Comment struct {
+	ID        uint       `json:"id"`
+	TaskID    uint       `json:"task_id" gorm:"index;not null"`
+	AuthorID  uint       `json:"author_id" gorm:"index;not null"`
+	Body      string     `json:"body" gorm:"type:text;not null"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// CommentEdit keeps the body a comment had before one of its edits
+type CommentEdit struct {
+	ID        uint      `json:"id"`
+	CommentID uint      `json:"comment_id" gorm:"index;not null"`
+	EditorID  uint      `json:"editor_id"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"edited_at"`
+}
+
+// Attachment is the metadata of a file uploaded to a task, its contents are kept in
+// the blob store under StorageKey
+type Attachment struct {
+	ID          uint      `json:"id"`
+	TaskID      uint      `json:"task_id" gorm:"index;not null"`
+	UploaderID  uint      `json:"uploader_id" gorm:"index;not null"`
+	FileName    string    `json:"file_name" gorm:"not null"`
+	ContentType string    `json:"content_type" gorm:"not null"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"-" gorm:"uniqueIndex;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Notification event types, each can be switched off in the user's preferences
+const (
+	NotificationMention    = "mention"
+	NotificationAssignment = "assignment"
+	NotificationStatus     = "status"
+	NotificationReminder   = "reminder"
+)
+
+// NotificationTypes lists every notification event type
+var NotificationTypes = []string{NotificationMention, NotificationAssignment, NotificationStatus, NotificationReminder}
+
+// IsValidNotificationType checks if the notification event type is known
+func IsValidNotificationType(kind string) bool {
+	for _, t := range NotificationTypes {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is an entry of a user's in-app inbox about something another user
+// did on a task. CommentID is set for mentions in comments.
+type Notification struct {
+	ID        uint       `json:"id"`
+	UserID    uint       `json:"-" gorm:"index;not null"`
+	Type      string     `json:"type" gorm:"type:varchar(20);not null"`
+	TaskID    uint       `json:"task_id" gorm:"index"`
+	CommentID *uint      `json:"comment_id,omitempty"`
+	ActorID   uint       `json:"actor_id"`
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NotificationPreference turns an event type on or off for a user. Types without
+// a row are on.
+type NotificationPreference struct {
+	UserID  uint   `gorm:"primaryKey"`
+	Type    string `gorm:"primaryKey;type:varchar(20)"`
+	Enabled bool   `gorm:"not null"`
+}
+
+// TaskReminder records a due date reminder that went out, so each reminder is
+// sent once. Changing the due date starts a new set of reminders.
+type TaskReminder struct {
+	TaskID  uint      `gorm:"primaryKey"`
+	DueDate time.Time `gorm:"primaryKey"`
+	// OffsetMinutes is how long before the due date the reminder was set for,
+	// zero for the overdue reminder
+	OffsetMinutes int       `gorm:"primaryKey"`
+	SentAt        time.Time `gorm:"not null"`
+}
+
+// Webhook events, a subscription lists the ones it receives
+const (
+	WebhookTaskCreated       = "task.created"
+	WebhookTaskUpdated       = "task.updated"
+	WebhookTaskDeleted       = "task.deleted"
+	WebhookTaskStatusChanged = "task.status_changed"
+)
+
+// WebhookEvents lists every webhook event
+var WebhookEvents = []string{WebhookTaskCreated, WebhookTaskUpdated, WebhookTaskDeleted, WebhookTaskStatusChanged}
+
+// IsValidWebhookEvent checks if the webhook event is known
+func IsValidWebhookEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscription sends the events of the tasks its owner can see to URL.
+// Requests are signed with Secret, which is only shown when the subscription is created.
+type WebhookSubscription struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"-" gorm:"index;not null"`
+	URL       string    `json:"url" gorm:"not null"`
+	Secret    string    `json:"-" gorm:"not null"`
+	Events    []string  `json:"events" gorm:"serializer:json;type:jsonb;not null"`
+	Active    bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreatedWebhook is returned once, when the subscription is created
+type CreatedWebhook struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// Webhook delivery states
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is an event queued for a subscription and the log of its
+// delivery. Pending deliveries are attempted at NextAttemptAt.
+type WebhookDelivery struct {
+	ID             uint            `json:"id"`
+	SubscriptionID uint            `json:"subscription_id" gorm:"index;not null"`
+	Event          string          `json:"event" gorm:"type:varchar(40);not null"`
+	Payload        json.RawMessage `json:"payload" gorm:"serializer:json;type:jsonb;not null"`
+	Status         string          `json:"status" gorm:"type:varchar(20);not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int             `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty" gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	// RedeliveryOf is the delivery this one was manually sent again for
+	RedeliveryOf *uint     `json:"redelivery_of,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// FieldChange is the value of a task field before and after a change
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// TaskProgress is the share of completed subtasks below a task
+type TaskProgress struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Percent   int   `json:"percent"`
+}
+
+// Label is a coloured tag a user puts on tasks they can edit. Names are unique per user.
+type Label struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"owner_id" gorm:"index;not null"`
+	Name      string    `json:"name" gorm:"not null"`
+	Color     string    `json:"color" gorm:"type:varchar(7);not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskLabel is a row of the task label join table
+type TaskLabel struct {
+	TaskID    uint `gorm:"primaryKey"`
+	LabelID   uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// Label filter modes of TaskFilter.LabelMode
+const (
+	LabelModeAny = "any"
+	LabelModeAll = "all"
+)
+
+// TaskAssignee is a row of the task assignment join table
+type TaskAssignee struct {
+	TaskID     uint `gorm:"primaryKey"`
+	UserID     uint `gorm:"primaryKey;index"`
+	AssignedBy uint
+	CreatedAt  time.Time
+}
+
+// Project groups the tasks of one client or board. The owner is always a member.
+type Project struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name" gorm:"not null"`
+	Description string    `json:"description"`
+	OwnerID     uint      `json:"owner_id" gorm:"index;not null"`
+	Archived    bool      `json:"archived" gorm:"not null;default:false"`
+	MemberIDs   []uint    `json:"member_ids" gorm:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ProjectMember is a row of the project membership join table
+type ProjectMember struct {
+	ProjectID uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey;index"`
+	CreatedAt time.Time
+}
+
+// TaskFilter holds the list query of GetFilteredTasks.
+// A nil OwnerIDs means tasks of every user. With a non-zero MemberID, OwnerIDs
+// only applies to personal tasks and the tasks of that user's projects are
+// listed instead. ProjectID limits the list to one project, AssigneeID to the
+// tasks assigned to that user.
+type TaskFilter struct {
+	OwnerIDs   []uint
+	MemberID   uint
+	ProjectID  uint
+	AssigneeID uint
+	Status     string
+	Priorities []TaskPriority
+	Labels     []string
+	LabelMode  string
+	// Blocked keeps only blocked (true) or unblocked (false) tasks when set
+	Blocked      *bool
+	DueDateAfter string
+	SortBy       string
+	SortOrder    string
+	Page         int
+	Limit        int
 }
 
 type TaskWithTotal struct {
@@ -33,17 +490,51 @@ type TaskWithTotal struct {
 	Total int64  `json:"total"`
 }
 
+// TaskResponse is a task as listed by the task list endpoints
 type TaskResponse struct {
-	ID        uint   `json:"ID"`
-	Title     string `json:"Title"`
-	Status    string `json:"Status"`
-	CreatedAt string `json:"CreatedAt"`
-	UpdatedAt string `json:"UpdatedAt"`
-}
-
-// IsValidStatus checks if the task status is valid
-func IsValidStatus(status TaskStatus) bool {
-	return status == TaskStatusPending ||
-		status == TaskStatusInProgress ||
-		status == TaskStatusCompleted
+	ID           uint          `json:"ID"`
+	Title        string        `json:"Title"`
+	Status       string        `json:"Status"`
+	Priority     string        `json:"Priority"`
+	DueDate      *string       `json:"DueDate,omitempty"`
+	ProjectID    *uint         `json:"ProjectID,omitempty"`
+	AssigneeIDs  []uint        `json:"AssigneeIDs"`
+	Labels       []Label       `json:"Labels"`
+	ParentID     *uint         `json:"ParentID,omitempty"`
+	Progress     *TaskProgress `json:"Progress,omitempty"`
+	DependsOn    []uint        `json:"DependsOn"`
+	Blocked      bool          `json:"Blocked"`
+	CommentCount int64         `json:"CommentCount"`
+	CreatedAt    string        `json:"CreatedAt"`
+	UpdatedAt    string        `json:"UpdatedAt"`
+}
+
+// IsValidPriority checks if the task priority is valid
+func IsValidPriority(priority TaskPriority) bool {
+	return priority == TaskPriorityLow ||
+		priority == TaskPriorityMedium ||
+		priority == TaskPriorityHigh ||
+		priority == TaskPriorityUrgent
+}
+
+// IsValidSortField checks if tasks can be sorted by the field
+func IsValidSortField(field string) bool {
+	return taskSortFields[field]
+}
+
+// IsValidSortOrder checks if the sort order is asc or desc
+func IsValidSortOrder(order string) bool {
+	return order == "asc" || order == "desc"
+}
+
+// IsValidLabelMode checks if the label filter mode is any or all
+func IsValidLabelMode(mode string) bool {
+	return mode == LabelModeAny || mode == LabelModeAll
+}
+
+// IsValidRole checks if the user role is valid
+func IsValidRole(role Role) bool {
+	return role == RoleUser ||
+		role == RoleManager ||
+		role == RoleAdmin
 }
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusCategory groups workflow statuses by how far along a task is
+type StatusCategory string
+
+const (
+	StatusCategoryTodo  StatusCategory = "todo"
+	StatusCategoryDoing StatusCategory = "doing"
+	StatusCategoryDone  StatusCategory = "done"
+)
+
+// IsValidStatusCategory checks if the category is todo, doing or done
+func IsValidStatusCategory(category StatusCategory) bool {
+	return category == StatusCategoryTodo || category == StatusCategoryDoing || category == StatusCategoryDone
+}
+
+// WorkflowStatus is a status tasks can be in. Position orders the statuses, the
+// first todo status is given to new tasks that do not ask for one.
+type WorkflowStatus struct {
+	Name      TaskStatus     `json:"name" gorm:"primaryKey;type:varchar(50)"`
+	Category  StatusCategory `json:"category" gorm:"type:varchar(10);not null;index"`
+	Position  int            `json:"position"`
+	CreatedAt time.Time      `json:"-"`
+}
+
+// WorkflowTransition allows tasks to move from one status to another
+type WorkflowTransition struct {
+	From TaskStatus `json:"from" gorm:"primaryKey;column:from_status;type:varchar(50)"`
+	To   TaskStatus `json:"to" gorm:"primaryKey;column:to_status;type:varchar(50)"`
+}
+
+// Workflow is the set of statuses and the transitions allowed between them
+type Workflow struct {
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// TaskTransitions is the current status of a task and the statuses it can move to
+type TaskTransitions struct {
+	Status   TaskStatus       `json:"status"`
+	Category StatusCategory   `json:"category"`
+	Next     []WorkflowStatus `json:"next"`
+}
+
+// DefaultWorkflow is the workflow installed on first start: tasks move freely
+// between the three built-in statuses, except that completed tasks are reopened
+// by going back to In Progress.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: []WorkflowStatus{
+			{Name: TaskStatusPending, Category: StatusCategoryTodo, Position: 1},
+			{Name: TaskStatusInProgress, Category: StatusCategoryDoing, Position: 2},
+			{Name: TaskStatusCompleted, Category: StatusCategoryDone, Position: 3},
+		},
+		Transitions: []WorkflowTransition{
+			{From: TaskStatusPending, To: TaskStatusInProgress},
+			{From: TaskStatusPending, To: TaskStatusCompleted},
+			{From: TaskStatusInProgress, To: TaskStatusPending},
+			{From: TaskStatusInProgress, To: TaskStatusCompleted},
+			{From: TaskStatusCompleted, To: TaskStatusInProgress},
+		},
+	}
+}
+
+// Status looks up a status by name
+func (w *Workflow) Status(name TaskStatus) (WorkflowStatus, bool) {
+	for _, s := range w.Statuses {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// Category returns the category of a status, empty for unknown statuses
+func (w *Workflow) Category(name TaskStatus) StatusCategory {
+	s, _ := w.Status(name)
+	return s.Category
+}
+
+// InitialStatus is the first todo status
+func (w *Workflow) InitialStatus() TaskStatus {
+	for _, s := range w.Statuses {
+		if s.Category == StatusCategoryTodo {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// CanTransition reports whether a task may move from one status to another.
+// Staying in the same status is always allowed.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, tr := range w.Transitions {
+		if tr.From == from && tr.To == to {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses lists the statuses a task in status from can move to, in position order
+func (w *Workflow) NextStatuses(from TaskStatus) []WorkflowStatus {
+	next := []WorkflowStatus{}
+	for _, s := range w.Statuses {
+		if s.Name != from && w.CanTransition(from, s.Name) {
+			next = append(next, s)
+		}
+	}
+	return next
+}
+
+// Validate normalises the workflow and checks that it is usable: unique non-empty
+// names, known categories, at least one todo and one done status, and transitions
+// between known statuses only
+func (w *Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("a workflow needs statuses")
+	}
+	seen := make(map[TaskStatus]bool, len(w.Statuses))
+	categories := make(map[StatusCategory]bool)
+	for i := range w.Statuses {
+		s := &w.Statuses[i]
+		s.Name = TaskStatus(strings.TrimSpace(string(s.Name)))
+		if s.Name == "" || len(s.Name) > 50 {
+			return errors.New("status names must be 1-50 characters")
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("status %q is listed twice", s.Name)
+		}
+		if !IsValidStatusCategory(s.Category) {
+			return fmt.Errorf("status %q has an invalid category, use todo, doing or done", s.Name)
+		}
+		seen[s.Name] = true
+		categories[s.Category] = true
+		s.Position = i + 1
+	}
+	if !categories[StatusCategoryTodo] || !categories[StatusCategoryDone] {
+		return errors.New("a workflow needs at least one todo and one done status")
+	}
+	for i := range w.Transitions {
+		tr := &w.Transitions[i]
+		tr.From = TaskStatus(strings.TrimSpace(string(tr.From)))
+		tr.To = TaskStatus(strings.TrimSpace(string(tr.To)))
+		if !seen[tr.From] || !seen[tr.To] {
+			return fmt.Errorf("transition %q -> %q uses an unknown status", tr.From, tr.To)
+		}
+		if tr.From == tr.To {
+			return fmt.Errorf("transition %q -> %q does not change the status", tr.From, tr.To)
+		}
+	}
+	return nil
+}
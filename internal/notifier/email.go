@@ -0,0 +1,29 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/mailer"
+)
+
+// Email mails reminders to the username, which is the user's email address
+type Email struct {
+	Mailer mailer.Mailer
+}
+
+// NewEmail creates a notifier sending through m
+func NewEmail(m mailer.Mailer) *Email {
+	return &Email{Mailer: m}
+}
+
+// Notify implements Notifier
+func (e *Email) Notify(_ context.Context, r Reminder) error {
+	subject := fmt.Sprintf("Reminder: %q is due %s", r.TaskTitle, r.DueDate.UTC().Format(time.RFC1123))
+	if r.Overdue() {
+		subject = fmt.Sprintf("Overdue: %q was due %s", r.TaskTitle, r.DueDate.UTC().Format(time.RFC1123))
+	}
+	body := fmt.Sprintf("%s\n\nTask #%d: %s\nDue: %s\n", subject, r.TaskID, r.TaskTitle, r.DueDate.UTC().Format(time.RFC3339))
+	return e.Mailer.Send(r.Username, subject, body)
+}
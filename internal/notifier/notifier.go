@@ -0,0 +1,54 @@
+// Package notifier delivers due date reminders over the channels users are
+// reached on, such as email or a webhook.
+package notifier
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Reminder tells a user that a task is due soon or overdue
+type Reminder struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	TaskID    uint      `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	DueDate   time.Time `json:"due_date"`
+	// Before is how long before the due date the reminder was set for, zero for
+	// the reminder sent once the task is overdue
+	Before time.Duration `json:"-"`
+}
+
+// Overdue reports whether this is the reminder sent once the due date has passed
+func (r Reminder) Overdue() bool {
+	return r.Before == 0
+}
+
+// Notifier delivers reminders over one channel
+type Notifier interface {
+	Notify(ctx context.Context, reminder Reminder) error
+}
+
+// Func adapts a function to a Notifier
+type Func func(ctx context.Context, reminder Reminder) error
+
+// Notify implements Notifier
+func (f Func) Notify(ctx context.Context, reminder Reminder) error {
+	return f(ctx, reminder)
+}
+
+// Multi delivers every reminder over each of its notifiers. A failing channel
+// does not keep the others from being tried.
+type Multi []Notifier
+
+// Notify implements Notifier, returning the errors of all failed channels
+func (m Multi) Notify(ctx context.Context, reminder Reminder) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, reminder); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	var got map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	due := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := notifier.NewWebhook(server.URL).Notify(context.Background(), notifier.Reminder{
+		UserID: 2, Username: "jane@example.com", TaskID: 7, TaskTitle: "Report", DueDate: due, Before: time.Hour,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "task.due_soon", got["event"])
+	assert.Equal(t, float64(60), got["before_minutes"])
+	assert.Equal(t, "2024-05-01T12:00:00Z", got["due_date"])
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	err = notifier.NewWebhook(failing.URL).Notify(context.Background(), notifier.Reminder{DueDate: due})
+	assert.Error(t, err)
+}
+
+func TestMulti_TriesEveryChannel(t *testing.T) {
+	calls := 0
+	ok := notifier.Func(func(context.Context, notifier.Reminder) error {
+		calls++
+		return nil
+	})
+	down := notifier.Func(func(context.Context, notifier.Reminder) error { return errors.New("down") })
+
+	err := notifier.Multi{down, ok}.Notify(context.Background(), notifier.Reminder{})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,54 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook posts reminders as JSON to a URL. Any 2xx response counts as delivered.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhook creates a notifier posting to url
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// webhookPayload is the JSON body of a reminder webhook
+type webhookPayload struct {
+	Event string `json:"event"`
+	Reminder
+	BeforeMinutes int64 `json:"before_minutes"`
+}
+
+// Notify implements Notifier
+func (w *Webhook) Notify(ctx context.Context, r Reminder) error {
+	event := "task.due_soon"
+	if r.Overdue() {
+		event = "task.overdue"
+	}
+	body, err := json.Marshal(webhookPayload{Event: event, Reminder: r, BeforeMinutes: int64(r.Before / time.Minute)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("reminder webhook answered %s", resp.Status)
+	}
+	return nil
+}
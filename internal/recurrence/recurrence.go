@@ -0,0 +1,246 @@
+// Package recurrence parses and expands the subset of RFC 5545 recurrence rules
+// used by recurring tasks: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY
+// (daily and weekly rules only), UNTIL and COUNT.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequencies of a rule
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxInterval is the largest INTERVAL accepted
+const maxInterval = 1000
+
+// maxSteps bounds the candidates looked at for one occurrence, a valid rule
+// never needs more than a few
+const maxSteps = 1000
+
+// ErrInvalidRule is returned for rules outside the supported subset
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Rule is a parsed recurrence rule. Count is 0 and Until nil for endless series.
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time
+	Count    int
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10", with
+// or without the RRULE: prefix
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not NAME=VALUE", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrInvalidRule, name)
+		}
+		seen[name] = true
+		switch name {
+		case "FREQ":
+			if value != Daily && value != Weekly && value != Monthly {
+				return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRule)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > maxInterval {
+				return nil, fmt.Errorf("%w: INTERVAL must be 1-%d", ErrInvalidRule, maxInterval)
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be positive", ErrInvalidRule)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			days, err := parseByDay(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.ByDay = days
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, name)
+		}
+	}
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot both be given", ErrInvalidRule)
+	}
+	if rule.Freq == Monthly && len(rule.ByDay) > 0 {
+		return nil, fmt.Errorf("%w: BYDAY is only supported for DAILY and WEEKLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+// parseUntil reads a UNTIL date (20240131) or UTC date-time (20240131T120000Z).
+// A date alone lasts to the end of the day.
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		return until.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must look like 20240131 or 20240131T120000Z", ErrInvalidRule)
+}
+
+// parseByDay reads a comma separated list of weekdays, MO to SU
+func parseByDay(value string) ([]time.Weekday, error) {
+	set := make(map[time.Weekday]bool)
+	for _, name := range strings.Split(value, ",") {
+		day, ok := weekdays[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: BYDAY takes weekdays like MO,WE,FR", ErrInvalidRule)
+		}
+		set[day] = true
+	}
+	days := make([]time.Weekday, 0, len(set))
+	for day := range set {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return weekOffset(days[i]) < weekOffset(days[j]) })
+	return days, nil
+}
+
+// String formats the rule in its canonical form
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		names := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			names[i] = weekdayNames[day]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next lists up to n occurrences that follow start, which has to be an occurrence
+// of the series itself. UNTIL ends the list; COUNT is left to the caller, which
+// knows how many occurrences came before start.
+func (r *Rule) Next(start time.Time, n int) []time.Time {
+	var next []time.Time
+	current := start
+	for len(next) < n {
+		occurrence, ok := r.after(start, current)
+		if !ok || (r.Until != nil && occurrence.After(*r.Until)) {
+			break
+		}
+		next = append(next, occurrence)
+		current = occurrence
+	}
+	return next
+}
+
+// after finds the first occurrence later than current in the series anchored at start
+func (r *Rule) after(start, current time.Time) (time.Time, bool) {
+	switch r.Freq {
+	case Daily:
+		for step := 1; step <= maxSteps; step++ {
+			candidate := current.AddDate(0, 0, step*r.Interval)
+			if r.onDay(candidate.Weekday()) {
+				return candidate, true
+			}
+		}
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		// Weeks start on Monday. current is an occurrence, so its week is one the
+		// series runs in, as is every Interval-th week after it.
+		week := current.AddDate(0, 0, -weekOffset(current.Weekday()))
+		for step := 0; step <= maxSteps; step++ {
+			for _, day := range days {
+				candidate := week.AddDate(0, 0, weekOffset(day))
+				if candidate.After(current) {
+					return candidate, true
+				}
+			}
+			week = week.AddDate(0, 0, 7*r.Interval)
+		}
+	case Monthly:
+		// Months without the day of start, like February 30th, are skipped
+		months := monthsBetween(start, current)
+		for step := 1; step <= maxSteps; step++ {
+			m := months + step*r.Interval
+			candidate := time.Date(start.Year(), start.Month()+time.Month(m), start.Day(),
+				start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+			if candidate.Day() == start.Day() {
+				return candidate, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// onDay reports whether a daily rule runs on the weekday
+func (r *Rule) onDay(day time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// weekOffset is the number of days since Monday
+func weekOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// monthsBetween counts the calendar months from start to current
+func monthsBetween(start, current time.Time) int {
+	return (current.Year()-start.Year())*12 + int(current.Month()-start.Month())
+}
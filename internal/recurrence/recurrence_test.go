@@ -0,0 +1,89 @@
+package recurrence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/recurrence"
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func dates(times []time.Time) []string {
+	out := make([]string, len(times))
+	for i, t := range times {
+		out[i] = t.Format("2006-01-02 Mon 15:04")
+	}
+	return out
+}
+
+func TestParse(t *testing.T) {
+	rule, err := recurrence.Parse("RRULE:freq=weekly;byday=fr,mo;interval=2;count=4")
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4", rule.String())
+
+	rule, err = recurrence.Parse("FREQ=DAILY;UNTIL=20240131")
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=DAILY;UNTIL=20240131T235959Z", rule.String())
+
+	for _, invalid := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20240131",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;FREQ=WEEKLY",
+	} {
+		_, err := recurrence.Parse(invalid)
+		assert.ErrorIs(t, err, recurrence.ErrInvalidRule, invalid)
+	}
+}
+
+func TestNext_Daily(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=DAILY;INTERVAL=2")
+	assert.Equal(t, []string{"2024-05-03 Fri 09:00", "2024-05-05 Sun 09:00", "2024-05-07 Tue 09:00"},
+		dates(rule.Next(date("2024-05-01 09:00"), 3)))
+
+	// Every weekday
+	rule, _ = recurrence.Parse("FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR")
+	assert.Equal(t, []string{"2024-05-06 Mon 09:00", "2024-05-07 Tue 09:00"},
+		dates(rule.Next(date("2024-05-03 09:00"), 2)))
+}
+
+func TestNext_Weekly(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=WEEKLY")
+	assert.Equal(t, []string{"2024-05-08 Wed 09:00", "2024-05-15 Wed 09:00"},
+		dates(rule.Next(date("2024-05-01 09:00"), 2)))
+
+	// Every other week on Monday and Friday, starting on a Friday
+	rule, _ = recurrence.Parse("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR")
+	assert.Equal(t, []string{"2024-05-13 Mon 09:00", "2024-05-17 Fri 09:00", "2024-05-27 Mon 09:00"},
+		dates(rule.Next(date("2024-05-03 09:00"), 3)))
+}
+
+func TestNext_Monthly(t *testing.T) {
+	// Months without a 31st are skipped
+	rule, _ := recurrence.Parse("FREQ=MONTHLY")
+	assert.Equal(t, []string{"2024-03-31 Sun 09:00", "2024-05-31 Fri 09:00"},
+		dates(rule.Next(date("2024-01-31 09:00"), 2)))
+
+	rule, _ = recurrence.Parse("FREQ=MONTHLY;INTERVAL=3")
+	assert.Equal(t, []string{"2024-04-15 Mon 09:00", "2024-07-15 Mon 09:00"},
+		dates(rule.Next(date("2024-01-15 09:00"), 2)))
+}
+
+func TestNext_Until(t *testing.T) {
+	rule, _ := recurrence.Parse("FREQ=WEEKLY;UNTIL=20240515")
+	assert.Equal(t, []string{"2024-05-08 Wed 09:00", "2024-05-15 Wed 09:00"},
+		dates(rule.Next(date("2024-05-01 09:00"), 10)))
+}
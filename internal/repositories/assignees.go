@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpdateTaskAssignees implements, assigning a current assignee again is a no-op
+func (t *TaskRepository) UpdateTaskAssignees(taskID uint, assign, unassign []uint, assignedBy uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if len(unassign) > 0 {
+			if err := tx.Where("task_id = ? AND user_id IN ?", taskID, unassign).Delete(&models.TaskAssignee{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, userID := range assign {
+			row := models.TaskAssignee{TaskID: taskID, UserID: userID, AssignedBy: assignedBy}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// attachmentQuotaLock is the advisory lock class serialising the uploads of a user
+const attachmentQuotaLock = 21
+
+// CreateAttachment implements. Uploads of the same user are serialised so that
+// concurrent uploads cannot overshoot the quota, quota 0 means no limit.
+func (t *TaskRepository) CreateAttachment(attachment *models.Attachment, quota int64) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if quota > 0 {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", attachmentQuotaLock, attachment.UploaderID).Error; err != nil {
+				return err
+			}
+			used, err := attachmentUsage(tx, attachment.UploaderID)
+			if err != nil {
+				return err
+			}
+			if used+attachment.Size > quota {
+				return models.ErrStorageQuotaExceeded
+			}
+		}
+		return tx.Create(attachment).Error
+	})
+}
+
+// GetAttachments implements, the attachments of the task from oldest to newest
+func (t *TaskRepository) GetAttachments(taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	if err := t.DB.Where("task_id = ?", taskID).Order("id asc").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAttachmentByID implements
+func (t *TaskRepository) GetAttachmentByID(id uint) (*models.Attachment, error) {
+	var attachment models.Attachment
+	if err := t.DB.First(&attachment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// DeleteAttachment implements, the blob is left to the caller
+func (t *TaskRepository) DeleteAttachment(id uint) error {
+	result := t.DB.Delete(&models.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// GetAttachmentUsage implements, the bytes the user has uploaded
+func (t *TaskRepository) GetAttachmentUsage(userID uint) (int64, error) {
+	return attachmentUsage(t.DB, userID)
+}
+
+func attachmentUsage(db *gorm.DB, userID uint) (int64, error) {
+	var used int64
+	err := db.Model(&models.Attachment{}).Select("COALESCE(SUM(size), 0)").
+		Where("uploader_id = ?", userID).Scan(&used).Error
+	return used, err
+}
+
+// DeleteOrphanedAttachments implements. The attachments of tasks that were purged
+// are deleted, their storage keys are returned so the blobs can go as well.
+func (t *TaskRepository) DeleteOrphanedAttachments() ([]string, error) {
+	var keys []string
+	err := t.DB.Raw(`
+		DELETE FROM attachments a
+		WHERE NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.id = a.task_id)
+		RETURNING a.storage_key`).Scan(&keys).Error
+	return keys, err
+}
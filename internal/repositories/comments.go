@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateComment implements
+func (t *TaskRepository) CreateComment(comment *models.Comment) error {
+	return t.DB.Create(comment).Error
+}
+
+// GetComments implements, a page of the task's thread from oldest to newest
+func (t *TaskRepository) GetComments(taskID uint, page, limit int) ([]models.Comment, int64, error) {
+	var comments []models.Comment
+	var total int64
+	db := t.DB.Model(&models.Comment{}).Where("task_id = ?", taskID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id asc").Limit(limit).Offset((page - 1) * limit).Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// GetCommentByID implements
+func (t *TaskRepository) GetCommentByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := t.DB.First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// UpdateComment implements, saving the new body together with the edit that keeps the old one
+func (t *TaskRepository) UpdateComment(comment *models.Comment, edit *models.CommentEdit) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Comment{}).Where("id = ?", comment.ID).
+			Select("body", "edited_at", "updated_at").
+			Updates(comment)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrCommentNotFound
+		}
+		return tx.Create(edit).Error
+	})
+}
+
+// DeleteComment implements, the edits of the comment go with it
+func (t *TaskRepository) DeleteComment(id uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id = ?", id).Delete(&models.CommentEdit{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Comment{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrCommentNotFound
+		}
+		return nil
+	})
+}
+
+// GetCommentEdits implements, the earlier bodies of the comment from oldest to newest
+func (t *TaskRepository) GetCommentEdits(commentID uint) ([]models.CommentEdit, error) {
+	var edits []models.CommentEdit
+	if err := t.DB.Where("comment_id = ?", commentID).Order("id asc").Find(&edits).Error; err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+// loadCommentCounts fills CommentCount of the tasks
+func (t *TaskRepository) loadCommentCounts(tasks []models.Task, ids []uint, index map[uint]int) error {
+	var rows []struct {
+		TaskID uint
+		Count  int64
+	}
+	if err := t.DB.Model(&models.Comment{}).Select("task_id, count(*) as count").
+		Where("task_id IN ?", ids).Group("task_id").Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		tasks[index[row.TaskID]].CommentCount = row.Count
+	}
+	return nil
+}
+
+// deleteTaskComments removes the comments of the tasks selected by taskIDs and their edits
+func deleteTaskComments(tx *gorm.DB, taskIDs interface{}) error {
+	if err := tx.Where("comment_id IN (?)",
+		tx.Model(&models.Comment{}).Select("id").Where("task_id IN (?)", taskIDs)).
+		Delete(&models.CommentEdit{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("task_id IN (?)", taskIDs).Delete(&models.Comment{}).Error
+}
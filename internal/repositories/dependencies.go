@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openBlockers selects the dependency edges whose blocking task is not done, the
+// table is aliased d. Trashed tasks block nothing.
+func openBlockers(db *gorm.DB) *gorm.DB {
+	return db.Table("task_dependencies AS d").
+		Joins("JOIN tasks b ON b.id = d.depends_on_id AND b.deleted_at IS NULL").
+		Where("b.status NOT IN (" + doneStatuses + ")")
+}
+
+// AddTaskDependency implements. The graph is locked against concurrent inserts
+// while the new edge is checked for cycles, adding an existing edge is a no-op.
+func (t *TaskRepository) AddTaskDependency(dep *models.TaskDependency) error {
+	if dep.TaskID == dep.DependsOnID {
+		return models.ErrDependencyCycle
+	}
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE task_dependencies IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+		// A cycle closes when the blocking task already waits on the task
+		var cycle bool
+		err := tx.Raw(`
+			WITH RECURSIVE reachable(id) AS (
+				SELECT depends_on_id FROM task_dependencies WHERE task_id = ?
+				UNION
+				SELECT d.depends_on_id FROM task_dependencies d JOIN reachable r ON d.task_id = r.id
+			)
+			SELECT EXISTS (SELECT 1 FROM reachable WHERE id = ?)`, dep.DependsOnID, dep.TaskID).Scan(&cycle).Error
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return models.ErrDependencyCycle
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(dep).Error
+	})
+}
+
+// RemoveTaskDependency implements
+func (t *TaskRepository) RemoveTaskDependency(taskID, dependsOnID uint) error {
+	result := t.DB.Where("task_id = ? AND depends_on_id = ?", taskID, dependsOnID).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// GetOpenBlockerIDs implements, the tasks the task depends on that are not done
+func (t *TaskRepository) GetOpenBlockerIDs(taskID uint) ([]uint, error) {
+	var ids []uint
+	err := openBlockers(t.DB).Where("d.task_id = ?", taskID).Order("d.depends_on_id asc").Pluck("d.depends_on_id", &ids).Error
+	return ids, err
+}
+
+// GetDependentTaskIDs implements, the tasks waiting on the task
+func (t *TaskRepository) GetDependentTaskIDs(taskID uint) ([]uint, error) {
+	var ids []uint
+	err := t.DB.Model(&models.TaskDependency{}).Where("depends_on_id = ?", taskID).Pluck("task_id", &ids).Error
+	return ids, err
+}
+
+// loadTaskDependencies fills DependsOn and Blocked of the tasks, leaving out
+// trashed tasks
+func (t *TaskRepository) loadTaskDependencies(tasks []models.Task, ids []uint, index map[uint]int) error {
+	var rows []struct {
+		TaskID      uint
+		DependsOnID uint
+		Done        bool
+	}
+	if err := t.DB.Table("task_dependencies AS d").
+		Select("d.task_id, d.depends_on_id, b.status IN ("+doneStatuses+") AS done").
+		Joins("JOIN tasks b ON b.id = d.depends_on_id AND b.deleted_at IS NULL").
+		Where("d.task_id IN ?", ids).
+		Order("d.depends_on_id asc").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		task := &tasks[index[row.TaskID]]
+		task.DependsOn = append(task.DependsOn, row.DependsOnID)
+		if !row.Done {
+			task.Blocked = true
+		}
+	}
+	return nil
+}
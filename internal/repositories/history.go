@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateTaskHistory implements. History is append only, there is no update or delete.
+func (t *TaskRepository) CreateTaskHistory(entry *models.TaskHistory) error {
+	return t.DB.Create(entry).Error
+}
+
+// GetTaskHistory implements, a page of the task's history from oldest to newest
+func (t *TaskRepository) GetTaskHistory(taskID uint, page, limit int) ([]models.TaskHistory, int64, error) {
+	var entries []models.TaskHistory
+	var total int64
+	db := t.DB.Model(&models.TaskHistory{}).Where("task_id = ?", taskID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id asc").Limit(limit).Offset((page - 1) * limit).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// GetLastTaskHistory implements, ErrTaskNotFound when the task has no history
+func (t *TaskRepository) GetLastTaskHistory(taskID uint) (*models.TaskHistory, error) {
+	var entry models.TaskHistory
+	if err := t.DB.Where("task_id = ?", taskID).Order("id desc").First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// recordUserTaskHistory writes the history entries of the tasks of a deleted user:
+// an owner change when they move to reassignTo, a deletion otherwise
+func recordUserTaskHistory(tx *gorm.DB, userID uint, reassignTo *uint, actorID uint) error {
+	if reassignTo != nil {
+		return tx.Exec(`
+			INSERT INTO task_histories (task_id, actor_id, action, changes, owner_id, project_id, created_at)
+			SELECT id, ?, ?, jsonb_build_array(jsonb_build_object('field', 'owner', 'before', user_id, 'after', ?::bigint)),
+				?, project_id, NOW()
+			FROM tasks WHERE user_id = ?`,
+			actorID, models.HistoryUpdated, *reassignTo, *reassignTo, userID).Error
+	}
+	return tx.Exec(`
+		INSERT INTO task_histories (task_id, actor_id, action, changes, owner_id, project_id, created_at)
+		SELECT id, ?, ?, jsonb_build_array(jsonb_build_object('field', 'title', 'before', title, 'after', NULL)),
+			user_id, project_id, NOW()
+		FROM tasks WHERE user_id = ? AND deleted_at IS NULL`,
+		actorID, models.HistoryDeleted, userID).Error
+}
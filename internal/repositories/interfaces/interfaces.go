@@ -1,19 +1,137 @@
 package interfaces
 
-import "github.com/ratheeshkumar25/task-mgt/internal/models"
+import (
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
 
 type TaskRepoInter interface {
 	//user repo
 	CreateUser(user *models.Users) error
 	FindUserByID(userID uint) (*models.Users, error)
 	GetUserByUsername(usename string) (*models.Users, error)
-	GetUserList() ([]*models.Users, error)
+	GetUserList(search string, page, limit int) ([]*models.Users, int64, error)
+	CountTasksByStatus(userID uint) (map[models.TaskStatus]int64, error)
+	UpdateUserFlags(userID uint, fields map[string]interface{}) error
+	DeleteUser(userID uint, reassignTo *uint, actorID uint) error
+	ReplaceRecoveryCodes(userID uint, hashes []string) error
+	UseRecoveryCode(userID uint, hash string) (bool, error)
+	GetTeamMemberIDs(managerID uint) ([]uint, error)
+	UpdateUserRole(userID uint, role models.Role, managerID *uint) error
+
+	//api key repo
+	CreateAPIKey(key *models.APIKey) error
+	ListAPIKeys(userID uint) ([]models.APIKey, error)
+	GetAPIKeyByPrefix(prefix string) (*models.APIKey, error)
+	RevokeAPIKey(userID, id uint) error
+	TouchAPIKey(id uint, usedAt time.Time) error
+
+	//project repo
+	CreateProject(project *models.Project) error
+	GetProjectByID(id uint) (*models.Project, error)
+	ListProjects(memberID uint, includeArchived bool) ([]models.Project, error)
+	UpdateProject(project *models.Project) error
+	DeleteProject(id uint, detach []uint) error
+	AddProjectMember(projectID, userID uint) error
+	RemoveProjectMember(projectID, userID uint) error
+	IsProjectMember(projectID, userID uint) (bool, error)
+
+	//label repo
+	CreateLabel(label *models.Label) error
+	ListLabels(userID uint) ([]models.Label, error)
+	GetLabelByID(id uint) (*models.Label, error)
+	FindLabelByName(userID uint, name string) (*models.Label, error)
+	UpdateLabel(label *models.Label) error
+	DeleteLabel(id uint) error
+	AttachLabels(taskID uint, labelIDs []uint) error
+	DetachLabel(taskID, labelID uint) error
+	GetLabelTaskIDs(labelID uint) ([]uint, error)
+
+	//subtask repo
+	GetSubtasks(parentID uint) ([]models.Task, error)
+	GetTaskAncestorIDs(id uint) ([]uint, error)
+	GetSubtaskHeight(id uint) (int, error)
+	GetSubtaskProgress(taskIDs []uint) (map[uint]models.TaskProgress, error)
+
+	//dependency repo
+	AddTaskDependency(dep *models.TaskDependency) error
+	RemoveTaskDependency(taskID, dependsOnID uint) error
+	GetOpenBlockerIDs(taskID uint) ([]uint, error)
+	GetDependentTaskIDs(taskID uint) ([]uint, error)
+
+	//workflow repo
+	GetWorkflow() (*models.Workflow, error)
+	ReplaceWorkflow(workflow *models.Workflow) error
+
+	//history repo
+	CreateTaskHistory(entry *models.TaskHistory) error
+	GetTaskHistory(taskID uint, page, limit int) ([]models.TaskHistory, int64, error)
+	GetLastTaskHistory(taskID uint) (*models.TaskHistory, error)
+
+	//comment repo
+	CreateComment(comment *models.Comment) error
+	GetComments(taskID uint, page, limit int) ([]models.Comment, int64, error)
+	GetCommentByID(id uint) (*models.Comment, error)
+	UpdateComment(comment *models.Comment, edit *models.CommentEdit) error
+	DeleteComment(id uint) error
+	GetCommentEdits(commentID uint) ([]models.CommentEdit, error)
+
+	//attachment repo
+	CreateAttachment(attachment *models.Attachment, quota int64) error
+	GetAttachments(taskID uint) ([]models.Attachment, error)
+	GetAttachmentByID(id uint) (*models.Attachment, error)
+	DeleteAttachment(id uint) error
+	GetAttachmentUsage(userID uint) (int64, error)
+	DeleteOrphanedAttachments() ([]string, error)
+
+	//notification repo
+	CreateNotifications(notifications []models.Notification) error
+	GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]models.Notification, int64, error)
+	CountUnreadNotifications(userID uint) (int64, error)
+	MarkNotificationRead(userID, id uint) error
+	MarkAllNotificationsRead(userID uint) (int64, error)
+	GetNotificationPreferences(userID uint) ([]models.NotificationPreference, error)
+	SetNotificationPreferences(userID uint, prefs map[string]bool) error
+	GetMutedUserIDs(kind string, userIDs []uint) ([]uint, error)
+	GetUsersByUsernames(usernames []string) ([]models.Users, error)
+
+	//reminder repo
+	GetDueTasks(from, to time.Time, excludeStatuses []models.TaskStatus) ([]models.Task, error)
+	ClaimReminder(reminder *models.TaskReminder) (bool, error)
+	ReleaseReminder(reminder *models.TaskReminder) error
+	GetUsersByIDs(ids []uint) ([]models.Users, error)
+
+	//recurrence repo
+	CreateOccurrence(task *models.Task) (bool, error)
+	StopTaskSeries(seriesID uint) ([]uint, error)
+
+	//webhook repo
+	CreateWebhook(sub *models.WebhookSubscription) error
+	GetWebhooks(userID uint) ([]models.WebhookSubscription, error)
+	GetWebhookByID(id uint) (*models.WebhookSubscription, error)
+	UpdateWebhook(sub *models.WebhookSubscription) error
+	DeleteWebhook(id uint) error
+	GetActiveWebhooks(event string) ([]models.WebhookSubscription, error)
+	CreateWebhookDeliveries(deliveries []models.WebhookDelivery) error
+	ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateWebhookDelivery(delivery *models.WebhookDelivery) error
+	GetWebhookDeliveries(subscriptionID uint, page, limit int) ([]models.WebhookDelivery, int64, error)
+	GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error)
+
+	//trash repo
+	GetTrashedTasks(userID uint, page, limit int) ([]models.Task, int64, error)
+	GetTrashedTask(id uint) (*models.Task, error)
+	RestoreTask(task *models.Task) error
+	PurgeTask(userID, id uint) error
+	PurgeTrashedTasks(userID uint, deletedBefore time.Time) (int64, error)
 
 	//task repo
 	CreateTask(task *models.Task) error
-	GetFilteredTasks(status, dueDateAfter, sortBy, sortOrder string, page, limit int) ([]models.Task, int64, error)
+	GetFilteredTasks(filter models.TaskFilter) ([]models.Task, int64, error)
 	//GetAllTask() ([]models.Task, error)
 	GetTaskByID(id uint) (*models.Task, error)
 	UpdateTask(task *models.Task) error
-	DeleteTask(id uint) error
+	DeleteTask(userID, id uint) error
+	UpdateTaskAssignees(taskID uint, assign, unassign []uint, assignedBy uint) error
 }
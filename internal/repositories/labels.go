@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateLabel implements
+func (t *TaskRepository) CreateLabel(label *models.Label) error {
+	return t.DB.Create(label).Error
+}
+
+// ListLabels implements
+func (t *TaskRepository) ListLabels(userID uint) ([]models.Label, error) {
+	var labels []models.Label
+	if err := t.DB.Where("user_id = ?", userID).Order("name asc").Find(&labels).Error; err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// GetLabelByID implements
+func (t *TaskRepository) GetLabelByID(id uint) (*models.Label, error) {
+	var label models.Label
+	if err := t.DB.First(&label, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+// FindLabelByName implements, names match case-insensitively
+func (t *TaskRepository) FindLabelByName(userID uint, name string) (*models.Label, error) {
+	var label models.Label
+	if err := t.DB.Where("user_id = ? AND LOWER(name) = LOWER(?)", userID, name).First(&label).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+// UpdateLabel implements
+func (t *TaskRepository) UpdateLabel(label *models.Label) error {
+	result := t.DB.Model(&models.Label{}).Where("id = ?", label.ID).
+		Select("name", "color").Updates(label)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrLabelNotFound
+	}
+	return nil
+}
+
+// DeleteLabel implements, the label is detached from every task first
+func (t *TaskRepository) DeleteLabel(id uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", id).Delete(&models.TaskLabel{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Label{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrLabelNotFound
+		}
+		return nil
+	})
+}
+
+// AttachLabels implements, attaching a label twice is a no-op
+func (t *TaskRepository) AttachLabels(taskID uint, labelIDs []uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		for _, labelID := range labelIDs {
+			row := models.TaskLabel{TaskID: taskID, LabelID: labelID}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DetachLabel implements
+func (t *TaskRepository) DetachLabel(taskID, labelID uint) error {
+	return t.DB.Where("task_id = ? AND label_id = ?", taskID, labelID).Delete(&models.TaskLabel{}).Error
+}
+
+// GetLabelTaskIDs implements
+func (t *TaskRepository) GetLabelTaskIDs(labelID uint) ([]uint, error) {
+	var ids []uint
+	if err := t.DB.Model(&models.TaskLabel{}).Where("label_id = ?", labelID).Pluck("task_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// loadTaskRelations fills the assignees, labels, dependencies, subtask progress and
+// comment counts of the tasks with one query each
+func (t *TaskRepository) loadTaskRelations(tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]uint, len(tasks))
+	index := make(map[uint]int, len(tasks))
+	for i := range tasks {
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+		tasks[i].AssigneeIDs = []uint{}
+		tasks[i].Labels = []models.Label{}
+		tasks[i].DependsOn = []uint{}
+	}
+
+	var assignees []models.TaskAssignee
+	if err := t.DB.Where("task_id IN ?", ids).Order("user_id asc").Find(&assignees).Error; err != nil {
+		return err
+	}
+	for _, a := range assignees {
+		task := &tasks[index[a.TaskID]]
+		task.AssigneeIDs = append(task.AssigneeIDs, a.UserID)
+	}
+
+	var rows []struct {
+		TaskID uint
+		models.Label
+	}
+	if err := t.DB.Table("task_labels").
+		Select("task_labels.task_id, labels.*").
+		Joins("JOIN labels ON labels.id = task_labels.label_id").
+		Where("task_labels.task_id IN ?", ids).
+		Order("labels.name asc").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		task := &tasks[index[row.TaskID]]
+		task.Labels = append(task.Labels, row.Label)
+	}
+	if err := t.loadTaskDependencies(tasks, ids, index); err != nil {
+		return err
+	}
+	if err := t.loadCommentCounts(tasks, ids, index); err != nil {
+		return err
+	}
+	return t.loadTaskProgress(tasks, ids, index)
+}
+
+// deleteUserLabels hands the labels of a deleted user to reassignTo, or deletes
+// them when it is nil
+func (t *TaskRepository) deleteUserLabels(tx *gorm.DB, userID uint, reassignTo *uint) error {
+	if reassignTo != nil {
+		return tx.Model(&models.Label{}).Where("user_id = ?", userID).Update("user_id", *reassignTo).Error
+	}
+	owned := tx.Model(&models.Label{}).Select("id").Where("user_id = ?", userID)
+	if err := tx.Where("label_id IN (?)", owned).Delete(&models.TaskLabel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ?", userID).Delete(&models.Label{}).Error
+}
+
+func lowerAll(names []string) []string {
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+	}
+	return lowered
+}
+
+func uniqueLower(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
+
+// deleteTaskRelations removes the rows hanging off the tasks selected by taskIDs,
+// a subquery or slice of task IDs
+func deleteTaskRelations(tx *gorm.DB, taskIDs interface{}) error {
+	if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.TaskAssignee{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("task_id IN (?) OR depends_on_id IN (?)", taskIDs, taskIDs).Delete(&models.TaskDependency{}).Error; err != nil {
+		return err
+	}
+	if err := deleteTaskComments(tx, taskIDs); err != nil {
+		return err
+	}
+	if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.Notification{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.TaskReminder{}).Error; err != nil {
+		return err
+	}
+	// Subtasks of other owners become top level tasks
+	if err := tx.Unscoped().Model(&models.Task{}).Where("parent_id IN (?)", taskIDs).Update("parent_id", nil).Error; err != nil {
+		return err
+	}
+	return tx.Where("task_id IN (?)", taskIDs).Delete(&models.TaskLabel{}).Error
+}
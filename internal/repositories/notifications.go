@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// CreateNotifications implements
+func (t *TaskRepository) CreateNotifications(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return t.DB.Create(&notifications).Error
+}
+
+// GetNotifications implements, a page of the user's inbox, newest first
+func (t *TaskRepository) GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+	db := t.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id desc").Limit(limit).Offset((page - 1) * limit).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// CountUnreadNotifications implements
+func (t *TaskRepository) CountUnreadNotifications(userID uint) (int64, error) {
+	var unread int64
+	err := t.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&unread).Error
+	return unread, err
+}
+
+// MarkNotificationRead implements, marking a read notification again is a no-op
+func (t *TaskRepository) MarkNotificationRead(userID, id uint) error {
+	result := t.DB.Model(&models.Notification{}).Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead implements, returning how many were unread
+func (t *TaskRepository) MarkAllNotificationsRead(userID uint) (int64, error) {
+	result := t.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// GetNotificationPreferences implements, only the types the user has set are returned
+func (t *TaskRepository) GetNotificationPreferences(userID uint) ([]models.NotificationPreference, error) {
+	var prefs []models.NotificationPreference
+	if err := t.DB.Where("user_id = ?", userID).Find(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// SetNotificationPreferences implements
+func (t *TaskRepository) SetNotificationPreferences(userID uint, prefs map[string]bool) error {
+	if len(prefs) == 0 {
+		return nil
+	}
+	rows := make([]models.NotificationPreference, 0, len(prefs))
+	for kind, enabled := range prefs {
+		rows = append(rows, models.NotificationPreference{UserID: userID, Type: kind, Enabled: enabled})
+	}
+	return t.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(&rows).Error
+}
+
+// GetMutedUserIDs implements, the users among userIDs that turned the type off
+func (t *TaskRepository) GetMutedUserIDs(kind string, userIDs []uint) ([]uint, error) {
+	var muted []uint
+	if len(userIDs) == 0 {
+		return muted, nil
+	}
+	err := t.DB.Model(&models.NotificationPreference{}).
+		Where("type = ? AND enabled = ? AND user_id IN ?", kind, false, userIDs).
+		Pluck("user_id", &muted).Error
+	return muted, err
+}
+
+// GetUsersByUsernames implements, matching usernames case-insensitively
+func (t *TaskRepository) GetUsersByUsernames(usernames []string) ([]models.Users, error) {
+	var users []models.Users
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	if err := t.DB.Where("LOWER(username) IN ?", lowerAll(usernames)).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
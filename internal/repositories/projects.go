@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateProject implements, the owner becomes the first member
+func (t *TaskRepository) CreateProject(project *models.Project) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+		member := models.ProjectMember{ProjectID: project.ID, UserID: project.OwnerID}
+		if err := tx.Create(&member).Error; err != nil {
+			return err
+		}
+		project.MemberIDs = []uint{project.OwnerID}
+		return nil
+	})
+}
+
+// GetProjectByID implements, MemberIDs is filled in
+func (t *TaskRepository) GetProjectByID(id uint) (*models.Project, error) {
+	var project models.Project
+	if err := t.DB.First(&project, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrProjectNotFound
+		}
+		return nil, err
+	}
+	if err := t.DB.Model(&models.ProjectMember{}).Where("project_id = ?", id).
+		Order("user_id asc").Pluck("user_id", &project.MemberIDs).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// ListProjects implements, memberID 0 lists the projects of every user
+func (t *TaskRepository) ListProjects(memberID uint, includeArchived bool) ([]models.Project, error) {
+	var projects []models.Project
+	db := t.DB.Model(&models.Project{})
+	if memberID != 0 {
+		db = db.Where("id IN (?)", t.DB.Model(&models.ProjectMember{}).Select("project_id").Where("user_id = ?", memberID))
+	}
+	if !includeArchived {
+		db = db.Where("archived = ?", false)
+	}
+	if err := db.Order("name asc").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject implements
+func (t *TaskRepository) UpdateProject(project *models.Project) error {
+	result := t.DB.Model(&models.Project{}).Where("id = ?", project.ID).
+		Select("name", "description", "archived", "updated_at").
+		Updates(project)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrProjectNotFound
+	}
+	return nil
+}
+
+// DeleteProject removes a project and its memberships. Its tasks, trashed ones
+// included, are kept and go back to their owners' personal lists; the subtasks in
+// detach become top level tasks.
+func (t *TaskRepository) DeleteProject(id uint, detach []uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if len(detach) > 0 {
+			if err := tx.Model(&models.Task{}).Where("id IN ? AND project_id = ?", detach, id).
+				Update("parent_id", nil).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Model(&models.Task{}).Where("project_id = ?", id).Update("project_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("project_id = ?", id).Delete(&models.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Project{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrProjectNotFound
+		}
+		return nil
+	})
+}
+
+// AddProjectMember implements, adding an existing member is a no-op
+func (t *TaskRepository) AddProjectMember(projectID, userID uint) error {
+	member := models.ProjectMember{ProjectID: projectID, UserID: userID}
+	return t.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&member).Error
+}
+
+// RemoveProjectMember implements, the user is also unassigned from the project's tasks
+func (t *TaskRepository) RemoveProjectMember(projectID, userID uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&models.ProjectMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ? AND task_id IN (?)", userID,
+			tx.Unscoped().Model(&models.Task{}).Select("id").Where("project_id = ?", projectID)).
+			Delete(&models.TaskAssignee{}).Error
+	})
+}
+
+// IsProjectMember implements
+func (t *TaskRepository) IsProjectMember(projectID, userID uint) (bool, error) {
+	var count int64
+	if err := t.DB.Model(&models.ProjectMember{}).
+		Where("project_id = ? AND user_id = ?", projectID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// deleteUserProjects drops the memberships of a deleted user and hands their
+// projects to reassignTo, or deletes them when it is nil
+func (t *TaskRepository) deleteUserProjects(tx *gorm.DB, userID uint, reassignTo *uint) error {
+	var owned []uint
+	if err := tx.Model(&models.Project{}).Where("owner_id = ?", userID).Pluck("id", &owned).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ?", userID).Delete(&models.ProjectMember{}).Error; err != nil {
+		return err
+	}
+	if len(owned) == 0 {
+		return nil
+	}
+
+	if reassignTo != nil {
+		if err := tx.Model(&models.Project{}).Where("id IN ?", owned).Update("owner_id", *reassignTo).Error; err != nil {
+			return err
+		}
+		for _, id := range owned {
+			member := models.ProjectMember{ProjectID: id, UserID: *reassignTo}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&member).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := tx.Unscoped().Model(&models.Task{}).Where("project_id IN ?", owned).Update("project_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("project_id IN ?", owned).Delete(&models.ProjectMember{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&models.Project{}, owned).Error
+}
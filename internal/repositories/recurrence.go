@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateOccurrence implements, false when the occurrence of the series already exists
+func (t *TaskRepository) CreateOccurrence(task *models.Task) (bool, error) {
+	result := t.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(task)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// StopTaskSeries implements, clearing the rule of every task in the series, trashed
+// ones included, and returning their ids
+func (t *TaskRepository) StopTaskSeries(seriesID uint) ([]uint, error) {
+	var ids []uint
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		series := tx.Unscoped().Model(&models.Task{}).
+			Where("(id = ? OR series_id = ?) AND recurrence <> ''", seriesID, seriesID)
+		if err := series.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Unscoped().Model(&models.Task{}).Where("id IN ?", ids).Update("recurrence", "").Error
+	})
+	return ids, err
+}
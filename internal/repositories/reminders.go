@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// GetDueTasks implements, the tasks due between from and to that are not in one
+// of excludeStatuses, soonest first
+func (t *TaskRepository) GetDueTasks(from, to time.Time, excludeStatuses []models.TaskStatus) ([]models.Task, error) {
+	var tasks []models.Task
+	db := t.DB.Where("due_date BETWEEN ? AND ?", from, to)
+	if len(excludeStatuses) > 0 {
+		db = db.Where("status NOT IN ?", excludeStatuses)
+	}
+	if err := db.Order("due_date asc").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	if err := t.loadTaskRelations(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ClaimReminder implements, false when the reminder has already been claimed
+func (t *TaskRepository) ClaimReminder(reminder *models.TaskReminder) (bool, error) {
+	result := t.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseReminder implements, so the reminder is tried again
+func (t *TaskRepository) ReleaseReminder(reminder *models.TaskReminder) error {
+	return t.DB.Where("task_id = ? AND due_date = ? AND offset_minutes = ?",
+		reminder.TaskID, reminder.DueDate, reminder.OffsetMinutes).
+		Delete(&models.TaskReminder{}).Error
+}
+
+// GetUsersByIDs implements, unknown ids are left out
+func (t *TaskRepository) GetUsersByIDs(ids []uint) ([]models.Users, error) {
+	var users []models.Users
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := t.DB.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -1,7 +1,10 @@
 package repositories
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ratheeshkumar25/task-mgt/internal/models"
 	inter "github.com/ratheeshkumar25/task-mgt/internal/repositories/interfaces"
@@ -22,13 +25,111 @@ func (t *TaskRepository) GetUserByUsername(usename string) (*models.Users, error
 	return &user, nil
 }
 
-// GetUserList implements
-func (t *TaskRepository) GetUserList() ([]*models.Users, error) {
+// GetUserList implements, search matches the username case-insensitively
+func (t *TaskRepository) GetUserList(search string, page, limit int) ([]*models.Users, int64, error) {
 	var user []*models.Users
-	if err := t.DB.Find(&user).Error; err != nil {
+	var total int64
+
+	db := t.DB.Model(&models.Users{})
+	if search != "" {
+		db = db.Where("username ILIKE ?", "%"+search+"%")
+	}
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if limit > 0 {
+		db = db.Limit(limit).Offset((page - 1) * limit)
+	}
+	if err := db.Order("id asc").Find(&user).Error; err != nil {
+		return nil, 0, err
+	}
+	return user, total, nil
+}
+
+// CountTasksByStatus returns the number of tasks a user owns per status
+func (t *TaskRepository) CountTasksByStatus(userID uint) (map[models.TaskStatus]int64, error) {
+	var rows []struct {
+		Status models.TaskStatus
+		Count  int64
+	}
+	if err := t.DB.Model(&models.Task{}).Select("status, count(*) as count").
+		Where("user_id = ?", userID).Group("status").Scan(&rows).Error; err != nil {
 		return nil, err
 	}
-	return user, nil
+	counts := make(map[models.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// UpdateUserFlags updates the account state columns of a user
+func (t *TaskRepository) UpdateUserFlags(userID uint, fields map[string]interface{}) error {
+	result := t.DB.Model(&models.Users{}).Where("id = ?", userID).Updates(fields)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
+
+// DeleteUser removes a user. Their tasks and projects move to reassignTo, or are
+// deleted when it is nil. The task history records actorID as the one who did it.
+func (t *TaskRepository) DeleteUser(userID uint, reassignTo *uint, actorID uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := recordUserTaskHistory(tx, userID, reassignTo, actorID); err != nil {
+			return err
+		}
+		// Trashed tasks move or go along with the others
+		tasks := tx.Unscoped().Model(&models.Task{}).Where("user_id = ?", userID)
+		if reassignTo != nil {
+			if err := tasks.Update("user_id", *reassignTo).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := deleteTaskRelations(tx, tx.Unscoped().Model(&models.Task{}).Select("id").Where("user_id = ?", userID)); err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.Task{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := t.deleteUserProjects(tx, userID, reassignTo); err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TaskAssignee{}).Error; err != nil {
+			return err
+		}
+		if err := t.deleteUserLabels(tx, userID, reassignTo); err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.NotificationPreference{}).Error; err != nil {
+			return err
+		}
+		if err := deleteUserWebhooks(tx, userID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Users{}).Where("manager_id = ?", userID).Update("manager_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&models.Users{}, userID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrUserNotFound
+		}
+		return nil
+	})
 }
 
 // Create User implements
@@ -43,11 +144,109 @@ func (t *TaskRepository) CreateUser(user *models.Users) error {
 func (t *TaskRepository) FindUserByID(userID uint) (*models.Users, error) {
 	var user models.Users
 	if err := t.DB.First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrUserNotFound
+		}
 		return nil, err
 	}
 	return &user, nil
 }
 
+// GetTeamMemberIDs returns the IDs of the users reporting to the manager
+func (t *TaskRepository) GetTeamMemberIDs(managerID uint) ([]uint, error) {
+	var ids []uint
+	if err := t.DB.Model(&models.Users{}).Where("manager_id = ?", managerID).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// UpdateUserRole sets the role and manager of a user
+func (t *TaskRepository) UpdateUserRole(userID uint, role models.Role, managerID *uint) error {
+	result := t.DB.Model(&models.Users{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"role": role, "manager_id": managerID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes swaps every recovery code of the user for the given hashes
+func (t *TaskRepository) ReplaceRecoveryCodes(userID uint, hashes []string) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(hashes) == 0 {
+			return nil
+		}
+		codes := make([]models.RecoveryCode, 0, len(hashes))
+		for _, hash := range hashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// UseRecoveryCode marks an unused code as used, reporting whether one matched
+func (t *TaskRepository) UseRecoveryCode(userID uint, hash string) (bool, error) {
+	result := t.DB.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CreateAPIKey implements
+func (t *TaskRepository) CreateAPIKey(key *models.APIKey) error {
+	return t.DB.Create(key).Error
+}
+
+// ListAPIKeys returns every key of the user, newest first
+func (t *TaskRepository) ListAPIKeys(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	if err := t.DB.Where("user_id = ?", userID).Order("created_at desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetAPIKeyByPrefix looks a key up by its public prefix
+func (t *TaskRepository) GetAPIKeyByPrefix(prefix string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := t.DB.Where("prefix = ?", prefix).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey marks a key of the user as revoked
+func (t *TaskRepository) RevokeAPIKey(userID, id uint) error {
+	result := t.DB.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records when a key was last used
+func (t *TaskRepository) TouchAPIKey(id uint, usedAt time.Time) error {
+	return t.DB.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
+
 // Create Task implements
 func (t *TaskRepository) CreateTask(task *models.Task) error {
 	if err := t.DB.Create(&task).Error; err != nil {
@@ -56,18 +255,59 @@ func (t *TaskRepository) CreateTask(task *models.Task) error {
 	return nil
 }
 
-func (r *TaskRepository) GetFilteredTasks(status, dueDateAfter, sortBy, sortOrder string, page, limit int) ([]models.Task, int64, error) {
+// GetFilteredTasks returns a page of tasks owned by filter.OwnerIDs
+func (r *TaskRepository) GetFilteredTasks(filter models.TaskFilter) ([]models.Task, int64, error) {
 	var tasks []models.Task
 	var total int64
 
 	db := r.DB.Model(&models.Task{})
 
+	// Ownership scope, project tasks are scoped by membership instead
+	if filter.MemberID != 0 {
+		memberProjects := r.DB.Model(&models.ProjectMember{}).Select("project_id").Where("user_id = ?", filter.MemberID)
+		if filter.OwnerIDs != nil {
+			db = db.Where("((project_id IS NULL AND user_id IN ?) OR project_id IN (?))", filter.OwnerIDs, memberProjects)
+		} else {
+			db = db.Where("(project_id IS NULL OR project_id IN (?))", memberProjects)
+		}
+	} else if filter.OwnerIDs != nil {
+		db = db.Where("user_id IN ?", filter.OwnerIDs)
+	}
+	if filter.ProjectID != 0 {
+		db = db.Where("project_id = ?", filter.ProjectID)
+	}
+	if filter.AssigneeID != 0 {
+		db = db.Where("id IN (?)",
+			r.DB.Model(&models.TaskAssignee{}).Select("task_id").Where("user_id = ?", filter.AssigneeID))
+	}
+	if len(filter.Labels) > 0 {
+		labelled := r.DB.Table("task_labels").Select("task_labels.task_id").
+			Joins("JOIN labels ON labels.id = task_labels.label_id").
+			Where("LOWER(labels.name) IN ?", lowerAll(filter.Labels))
+		if filter.LabelMode == models.LabelModeAll {
+			// Names repeat across users, so count distinct names per task
+			labelled = labelled.Group("task_labels.task_id").
+				Having("COUNT(DISTINCT LOWER(labels.name)) = ?", len(uniqueLower(filter.Labels)))
+		}
+		db = db.Where("id IN (?)", labelled)
+	}
+	if filter.Blocked != nil {
+		if *filter.Blocked {
+			db = db.Where("id IN (?)", openBlockers(r.DB).Select("d.task_id"))
+		} else {
+			db = db.Where("id NOT IN (?)", openBlockers(r.DB).Select("d.task_id"))
+		}
+	}
+
 	// Filters
-	if status != "" {
-		db = db.Where("status = ?", status)
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
 	}
-	if dueDateAfter != "" {
-		db = db.Where("due_date >= ?", dueDateAfter)
+	if len(filter.Priorities) > 0 {
+		db = db.Where("priority IN ?", filter.Priorities)
+	}
+	if filter.DueDateAfter != "" {
+		db = db.Where("due_date >= ?", filter.DueDateAfter)
 	}
 
 	// Get total count before pagination
@@ -76,24 +316,49 @@ func (r *TaskRepository) GetFilteredTasks(status, dueDateAfter, sortBy, sortOrde
 	}
 
 	// Sorting
-	if sortBy != "" && sortOrder != "" {
-		db = db.Order(fmt.Sprintf("%s %s", sortBy, sortOrder))
+	if order := taskOrder(filter.SortBy, filter.SortOrder); order != "" {
+		db = db.Order(order)
 	}
 
 	// Pagination
-	offset := (page - 1) * limit
-	if limit > 0 {
-		db = db.Limit(limit).Offset(offset)
+	offset := (filter.Page - 1) * filter.Limit
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit).Offset(offset)
 	}
 
 	// Final query
 	if err := db.Find(&tasks).Error; err != nil {
 		return nil, 0, err
 	}
+	if err := r.loadTaskRelations(tasks); err != nil {
+		return nil, 0, err
+	}
 
 	return tasks, total, nil
 }
 
+// priorityRank orders priorities from low to urgent
+var priorityRank = fmt.Sprintf("CASE priority WHEN '%s' THEN 1 WHEN '%s' THEN 2 WHEN '%s' THEN 3 WHEN '%s' THEN 4 ELSE 0 END",
+	models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh, models.TaskPriorityUrgent)
+
+// taskOrder builds the ORDER BY clause of a task list. Only whitelisted fields and
+// orders reach the query, anything else leaves the list unsorted.
+func taskOrder(sortBy, sortOrder string) string {
+	if sortBy == models.SortSmart {
+		// Overdue open tasks first, then the most urgent, then the closest due date
+		return fmt.Sprintf("CASE WHEN due_date < CURRENT_TIMESTAMP AND status NOT IN (%s) THEN 0 ELSE 1 END, %s DESC, due_date ASC NULLS LAST, id ASC",
+			doneStatuses, priorityRank)
+	}
+	if !models.IsValidSortField(sortBy) || !models.IsValidSortOrder(sortOrder) {
+		return ""
+	}
+	column := sortBy
+	if sortBy == "priority" {
+		column = priorityRank
+	}
+	return fmt.Sprintf("%s %s, id ASC", column, strings.ToUpper(sortOrder))
+}
+
 // // GetAll Task implements
 // func (t *TaskRepository) GetAllTask() ([]models.Task, error) {
 // 	var tasks []models.Task
@@ -103,35 +368,56 @@ func (r *TaskRepository) GetFilteredTasks(status, dueDateAfter, sortBy, sortOrde
 // 	return tasks, nil
 // }
 
-// Get Task ByID implements
+// Get Task ByID implements, access checks are left to the service layer
 func (t *TaskRepository) GetTaskByID(id uint) (*models.Task, error) {
 	var tasks models.Task
 	if err := t.DB.First(&tasks, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTaskNotFound
+		}
 		return nil, err
 	}
-	return &tasks, nil
-}
-
-// Update Task implements
-func (t *TaskRepository) UpdateTask(task *models.Task) error {
-	if err := t.DB.Save(&task).Error; err != nil {
-		return err
+	loaded := []models.Task{tasks}
+	if err := t.loadTaskRelations(loaded); err != nil {
+		return nil, err
 	}
-	return nil
+	return &loaded[0], nil
 }
 
-// Delete Task implements
-func (r *TaskRepository) DeleteTask(id uint) error {
-	result := r.DB.Delete(&models.Task{}, id)
+// Update Task implements, the row must belong to task.UserID
+func (t *TaskRepository) UpdateTask(task *models.Task) error {
+	result := t.DB.Model(&models.Task{}).
+		Where("id = ? AND user_id = ?", task.ID, task.UserID).
+		Select("title", "description", "status", "priority", "due_date", "reminder_minutes", "recurrence", "occurrence",
+			"project_id", "parent_id", "updated_at").
+		Updates(task)
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("task not found")
+		return models.ErrTaskNotFound
 	}
 	return nil
 }
 
+// Delete Task implements, the row must belong to userID. The task goes to the trash
+// with its assignees, labels and dependencies, its subtasks move up to its parent.
+func (r *TaskRepository) DeleteTask(userID, id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var task models.Task
+		if err := tx.Where("user_id = ?", userID).First(&task, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return models.ErrTaskNotFound
+			}
+			return err
+		}
+		if err := detachSubtasks(tx, &task); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Task{}, id).Error
+	})
+}
+
 // NewTaskRepository: Constructor function
 func NewTaskRepository(db *gorm.DB) inter.TaskRepoInter {
 	return &TaskRepository{
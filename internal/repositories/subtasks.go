@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// GetSubtasks implements, the direct subtasks of the task ordered by id
+func (t *TaskRepository) GetSubtasks(parentID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	if err := t.DB.Where("parent_id = ?", parentID).Order("id asc").Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	if err := t.loadTaskRelations(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetTaskAncestorIDs implements, the parent chain of the task starting with its parent
+func (t *TaskRepository) GetTaskAncestorIDs(id uint) ([]uint, error) {
+	var rows []struct {
+		ID    uint
+		Depth int
+	}
+	err := t.DB.Raw(`
+		WITH RECURSIVE ancestors(id, parent_id, depth) AS (
+			SELECT p.id, p.parent_id, 1 FROM tasks p JOIN tasks c ON c.parent_id = p.id WHERE c.id = ?
+			UNION ALL
+			SELECT p.id, p.parent_id, a.depth + 1 FROM tasks p JOIN ancestors a ON p.id = a.parent_id
+		)
+		SELECT id, depth FROM ancestors ORDER BY depth`, id).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	return ids, nil
+}
+
+// GetSubtaskHeight implements, the number of subtask levels below the task
+func (t *TaskRepository) GetSubtaskHeight(id uint) (int, error) {
+	var height int
+	err := t.DB.Raw(`
+		WITH RECURSIVE tree(id, depth) AS (
+			SELECT id, 1 FROM tasks WHERE parent_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT c.id, tree.depth + 1 FROM tasks c JOIN tree ON c.parent_id = tree.id WHERE c.deleted_at IS NULL
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM tree`, id).Scan(&height).Error
+	return height, err
+}
+
+// GetSubtaskProgress implements, counting the subtasks at every depth below each task
+func (t *TaskRepository) GetSubtaskProgress(taskIDs []uint) (map[uint]models.TaskProgress, error) {
+	progress := make(map[uint]models.TaskProgress)
+	if len(taskIDs) == 0 {
+		return progress, nil
+	}
+	var rows []struct {
+		RootID    uint
+		Total     int64
+		Completed int64
+	}
+	err := t.DB.Raw(`
+		WITH RECURSIVE tree(root_id, id, status) AS (
+			SELECT parent_id, id, status FROM tasks WHERE parent_id IN ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT tree.root_id, c.id, c.status FROM tasks c JOIN tree ON c.parent_id = tree.id WHERE c.deleted_at IS NULL
+		)
+		SELECT root_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status IN (`+doneStatuses+`)) AS completed
+		FROM tree GROUP BY root_id`, taskIDs).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		progress[row.RootID] = models.TaskProgress{
+			Total:     row.Total,
+			Completed: row.Completed,
+			Percent:   int(row.Completed * 100 / row.Total),
+		}
+	}
+	return progress, nil
+}
+
+// loadTaskProgress fills Progress of the tasks that have subtasks
+func (t *TaskRepository) loadTaskProgress(tasks []models.Task, ids []uint, index map[uint]int) error {
+	progress, err := t.GetSubtaskProgress(ids)
+	if err != nil {
+		return err
+	}
+	for id, p := range progress {
+		p := p
+		tasks[index[id]].Progress = &p
+	}
+	return nil
+}
+
+// detachSubtasks moves the subtasks of the deleted task up to its parent, trashed
+// subtasks included
+func detachSubtasks(tx *gorm.DB, task *models.Task) error {
+	return tx.Unscoped().Model(&models.Task{}).Where("parent_id = ?", task.ID).Update("parent_id", task.ParentID).Error
+}
@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// trashedTasks selects the tasks in the trash
+func trashedTasks(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Model(&models.Task{}).Where("deleted_at IS NOT NULL")
+}
+
+// GetTrashedTasks implements, a page of the user's trash, most recently deleted first
+func (t *TaskRepository) GetTrashedTasks(userID uint, page, limit int) ([]models.Task, int64, error) {
+	var tasks []models.Task
+	var total int64
+	db := trashedTasks(t.DB).Where("user_id = ?", userID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("deleted_at desc, id desc").Limit(limit).Offset((page - 1) * limit).Find(&tasks).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := t.loadTaskRelations(tasks); err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// GetTrashedTask implements, ErrTaskNotFound unless the task is in the trash
+func (t *TaskRepository) GetTrashedTask(id uint) (*models.Task, error) {
+	var task models.Task
+	if err := trashedTasks(t.DB).First(&task, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	loaded := []models.Task{task}
+	if err := t.loadTaskRelations(loaded); err != nil {
+		return nil, err
+	}
+	return &loaded[0], nil
+}
+
+// RestoreTask implements, taking the task of task.UserID out of the trash with
+// the given status and parent
+func (t *TaskRepository) RestoreTask(task *models.Task) error {
+	result := trashedTasks(t.DB).Where("id = ? AND user_id = ?", task.ID, task.UserID).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"status":     task.Status,
+			"parent_id":  task.ParentID,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrTaskNotFound
+	}
+	return nil
+}
+
+// PurgeTask implements, deleting a task of userID in the trash for good
+func (t *TaskRepository) PurgeTask(userID, id uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		result := trashedTasks(tx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Task{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrTaskNotFound
+		}
+		return deleteTaskRelations(tx, []uint{id})
+	})
+}
+
+// PurgeTrashedTasks implements, deleting the tasks trashed before deletedBefore for
+// good. userID 0 empties the trash of every user.
+func (t *TaskRepository) PurgeTrashedTasks(userID uint, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := t.DB.Transaction(func(tx *gorm.DB) error {
+		expired := func() *gorm.DB {
+			db := trashedTasks(tx).Where("deleted_at < ?", deletedBefore)
+			if userID != 0 {
+				db = db.Where("user_id = ?", userID)
+			}
+			return db
+		}
+		if err := deleteTaskRelations(tx, expired().Select("id")); err != nil {
+			return err
+		}
+		result := expired().Delete(&models.Task{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
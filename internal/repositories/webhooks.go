@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateWebhook implements
+func (t *TaskRepository) CreateWebhook(sub *models.WebhookSubscription) error {
+	return t.DB.Create(sub).Error
+}
+
+// GetWebhooks implements, the user's subscriptions in creation order
+func (t *TaskRepository) GetWebhooks(userID uint) ([]models.WebhookSubscription, error) {
+	var subs []models.WebhookSubscription
+	if err := t.DB.Where("user_id = ?", userID).Order("id asc").Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// GetWebhookByID implements
+func (t *TaskRepository) GetWebhookByID(id uint) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	if err := t.DB.First(&sub, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// UpdateWebhook implements
+func (t *TaskRepository) UpdateWebhook(sub *models.WebhookSubscription) error {
+	result := t.DB.Model(&models.WebhookSubscription{}).Where("id = ?", sub.ID).
+		Select("url", "events", "active", "updated_at").
+		Updates(sub)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DeleteWebhook implements, along with its delivery log
+func (t *TaskRepository) DeleteWebhook(id uint) error {
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.WebhookSubscription{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return models.ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+// deleteUserWebhooks deletes the subscriptions of a deleted user and their deliveries
+func deleteUserWebhooks(tx *gorm.DB, userID uint) error {
+	owned := tx.Model(&models.WebhookSubscription{}).Select("id").Where("user_id = ?", userID)
+	if err := tx.Where("subscription_id IN (?)", owned).Delete(&models.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("user_id = ?", userID).Delete(&models.WebhookSubscription{}).Error
+}
+
+// GetActiveWebhooks implements, the active subscriptions listing the event
+func (t *TaskRepository) GetActiveWebhooks(event string) ([]models.WebhookSubscription, error) {
+	var subs []models.WebhookSubscription
+	err := t.DB.Where("active = ? AND events @> CAST(? AS jsonb)", true, `["`+event+`"]`).
+		Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// CreateWebhookDeliveries implements
+func (t *TaskRepository) CreateWebhookDeliveries(deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return t.DB.Create(&deliveries).Error
+}
+
+// ClaimWebhookDeliveries implements. The pending deliveries due at now are pushed
+// to leaseUntil, so other workers skip them and a crashed worker's deliveries are
+// picked up again once the lease runs out.
+func (t *TaskRepository) ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := t.DB.Raw(`
+		UPDATE webhook_deliveries SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = ? AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED)
+		RETURNING *`, leaseUntil, models.WebhookDeliveryPending, now, limit).
+		Scan(&deliveries).Error
+	return deliveries, err
+}
+
+// UpdateWebhookDelivery implements, recording the outcome of an attempt
+func (t *TaskRepository) UpdateWebhookDelivery(delivery *models.WebhookDelivery) error {
+	return t.DB.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).
+		Select("status", "attempts", "next_attempt_at", "last_status_code", "last_error", "delivered_at", "updated_at").
+		Updates(delivery).Error
+}
+
+// GetWebhookDeliveries implements, a page of a subscription's log, newest first
+func (t *TaskRepository) GetWebhookDeliveries(subscriptionID uint, page, limit int) ([]models.WebhookDelivery, int64, error) {
+	var deliveries []models.WebhookDelivery
+	var total int64
+	db := t.DB.Model(&models.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := db.Order("id desc").Limit(limit).Offset((page - 1) * limit).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// GetWebhookDeliveryByID implements
+func (t *TaskRepository) GetWebhookDeliveryByID(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := t.DB.First(&delivery, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
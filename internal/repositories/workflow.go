@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"gorm.io/gorm"
+)
+
+// doneStatuses is a subquery selecting the names of the done statuses
+var doneStatuses = fmt.Sprintf("SELECT name FROM workflow_statuses WHERE category = '%s'", models.StatusCategoryDone)
+
+// GetWorkflow implements
+func (t *TaskRepository) GetWorkflow() (*models.Workflow, error) {
+	var workflow models.Workflow
+	if err := t.DB.Order("position asc").Find(&workflow.Statuses).Error; err != nil {
+		return nil, err
+	}
+	if err := t.DB.Order("from_status asc, to_status asc").Find(&workflow.Transitions).Error; err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// ReplaceWorkflow implements. Statuses that tasks are still in cannot be dropped.
+func (t *TaskRepository) ReplaceWorkflow(workflow *models.Workflow) error {
+	names := make([]models.TaskStatus, len(workflow.Statuses))
+	for i, s := range workflow.Statuses {
+		names[i] = s.Name
+	}
+	return t.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE workflow_statuses IN EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+		var inUse []models.TaskStatus
+		if err := tx.Model(&models.Task{}).Distinct("status").Where("status NOT IN ?", names).
+			Pluck("status", &inUse).Error; err != nil {
+			return err
+		}
+		if len(inUse) > 0 {
+			return fmt.Errorf("%w: %v", models.ErrStatusInUse, inUse)
+		}
+		if err := tx.Where("1 = 1").Delete(&models.WorkflowTransition{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&models.WorkflowStatus{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&workflow.Statuses).Error; err != nil {
+			return err
+		}
+		if len(workflow.Transitions) == 0 {
+			return nil
+		}
+		return tx.Create(&workflow.Transitions).Error
+	})
+}
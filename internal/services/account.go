@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Lifetimes of the links mailed to users
+const (
+	verifyEmailTTL   = 24 * time.Hour
+	passwordResetTTL = 30 * time.Minute
+)
+
+// minPasswordLength is enforced whenever a password is reset
+const minPasswordLength = 8
+
+// ErrInvalidActionToken is returned for expired, forged or already used email tokens
+var ErrInvalidActionToken = errors.New("invalid or expired token")
+
+// actionLink builds the URL mailed to the user for path with the token attached
+func (t *TaskServices) actionLink(path, token string) string {
+	return fmt.Sprintf("%s%s?token=%s", t.baseURL, path, url.QueryEscape(token))
+}
+
+// sendVerificationEmail mails a verification link bound to the user's address
+func (t *TaskServices) sendVerificationEmail(user *models.Users) error {
+	token, err := utility.GenerateActionToken(t.keys, utility.PurposeVerifyEmail, user.ID, user.Username, verifyEmailTTL)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Confirm your email address by opening the link below. It expires in 24 hours.\n\n%s\n",
+		t.actionLink("/api/v1/verify-email", token))
+	return t.mailer.Send(user.Username, "Verify your email address", body)
+}
+
+// consumeActionToken verifies a mailed token, checks it still matches the account
+// state it was issued for and marks it used
+func (t *TaskServices) consumeActionToken(purpose, token string, state func(*models.Users) string) (*models.Users, error) {
+	claims, err := utility.ParseActionToken(t.keys, purpose, token)
+	if err != nil {
+		return nil, ErrInvalidActionToken
+	}
+
+	user, err := t.Repo.FindUserByID(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidActionToken
+	}
+	if utility.Fingerprint(state(user)) != claims.Fingerprint {
+		return nil, ErrInvalidActionToken
+	}
+
+	fresh, err := t.tokens.MarkUsed(context.Background(), claims.Id, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrInvalidActionToken
+	}
+	return user, nil
+}
+
+// ResendVerificationEmail: Mails a new verification link unless the address is already verified
+func (t *TaskServices) ResendVerificationEmail(userID uint) error {
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return errors.New("email already verified")
+	}
+	return t.sendVerificationEmail(user)
+}
+
+// VerifyEmail: Marks the address of the token's user as verified
+func (t *TaskServices) VerifyEmail(token string) error {
+	user, err := t.consumeActionToken(utility.PurposeVerifyEmail, token, func(u *models.Users) string {
+		return u.Username
+	})
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return nil
+	}
+	return t.Repo.UpdateUserFlags(user.ID, map[string]interface{}{
+		"email_verified":    true,
+		"email_verified_at": time.Now(),
+	})
+}
+
+// ForgotPassword: Mails a reset link when the user exists. It never reports whether
+// the user exists, so the endpoint cannot be used to enumerate accounts.
+func (t *TaskServices) ForgotPassword(username string) error {
+	user, err := t.Repo.GetUserByUsername(username)
+	if err != nil || user.Disabled {
+		return nil
+	}
+
+	token, err := utility.GenerateActionToken(t.keys, utility.PurposePasswordReset, user.ID, user.PasswordHash, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("Someone asked to reset your password. Within 30 minutes, send the token below together with\n"+
+		"your new password to POST %s/api/v1/password/reset. If it was not you, ignore this email.\n\n%s\n", t.baseURL, token)
+	if err := t.mailer.Send(user.Username, "Reset your password", body); err != nil {
+		t.Logger.Println("failed to send password reset email:", err)
+	}
+	return nil
+}
+
+// ResetPassword: Sets a new password using a reset token and ends every existing session.
+// Completing a reset also proves ownership of the address.
+func (t *TaskServices) ResetPassword(token, newPassword string) error {
+	if len(newPassword) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPasswordLength)
+	}
+	user, err := t.consumeActionToken(utility.PurposePasswordReset, token, func(u *models.Users) string {
+		return u.PasswordHash
+	})
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{
+		"password_hash":       string(hash),
+		"must_reset_password": false,
+	}
+	if !user.EmailVerified {
+		fields["email_verified"] = true
+		fields["email_verified_at"] = time.Now()
+	}
+	if err := t.Repo.UpdateUserFlags(user.ID, fields); err != nil {
+		return err
+	}
+	if err := t.tokens.RevokeAllForUser(context.Background(), user.ID); err != nil {
+		t.Logger.Println("failed to revoke sessions after password reset:", err)
+	}
+	return nil
+}
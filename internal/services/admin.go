@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// requireAdmin rejects actors that are not admins
+func requireAdmin(actor models.Actor) error {
+	if actor.Role != models.RoleAdmin {
+		return models.ErrForbidden
+	}
+	return nil
+}
+
+// ListUsers: Pages through users, optionally filtered by a username search
+func (t *TaskServices) ListUsers(actor models.Actor, search string, page, limit int) ([]models.UserResponse, int64, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, 0, err
+	}
+	users, total, err := t.Repo.GetUserList(search, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := make([]models.UserResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(resp, user.ToResponse())
+	}
+	return resp, total, nil
+}
+
+// GetUserDetail: Returns a user with the number of tasks they own per status
+func (t *TaskServices) GetUserDetail(actor models.Actor, userID uint) (*models.UserDetail, error) {
+	if err := requireAdmin(actor); err != nil {
+		return nil, err
+	}
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := t.Repo.CountTasksByStatus(userID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &models.UserDetail{UserResponse: user.ToResponse(), TaskCounts: counts}
+	for _, n := range counts {
+		detail.TotalTasks += n
+	}
+	return detail, nil
+}
+
+// UpdateUserRole: Changes a user's role and manager, admins only. The user's
+// sessions are revoked so the new role takes effect on the next login.
+func (t *TaskServices) UpdateUserRole(actor models.Actor, userID uint, role models.Role, managerID *uint) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if !models.IsValidRole(role) {
+		return errors.New("invalid role")
+	}
+	if managerID != nil {
+		if *managerID == userID {
+			return errors.New("a user cannot manage themselves")
+		}
+		if _, err := t.Repo.FindUserByID(*managerID); err != nil {
+			return err
+		}
+	}
+	if err := t.Repo.UpdateUserRole(userID, role, managerID); err != nil {
+		return err
+	}
+	if err := t.tokens.RevokeAllForUser(context.Background(), userID); err != nil {
+		t.Logger.Println("failed to revoke sessions after role change:", err)
+	}
+	return nil
+}
+
+// SetUserDisabled: Disables or re-enables an account. Disabling also ends every session.
+func (t *TaskServices) SetUserDisabled(actor models.Actor, userID uint, disabled bool) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if disabled && userID == actor.UserID {
+		return errors.New("admins cannot disable their own account")
+	}
+	if err := t.Repo.UpdateUserFlags(userID, map[string]interface{}{"disabled": disabled}); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := t.tokens.SetUserDisabled(ctx, userID, disabled); err != nil {
+		return err
+	}
+	if disabled {
+		if err := t.tokens.RevokeAllForUser(ctx, userID); err != nil {
+			t.Logger.Println("failed to revoke sessions of disabled user:", err)
+		}
+	}
+	return nil
+}
+
+// ForcePasswordReset: Ends every session of the user and blocks login until the password is reset
+func (t *TaskServices) ForcePasswordReset(actor models.Actor, userID uint) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if err := t.Repo.UpdateUserFlags(userID, map[string]interface{}{"must_reset_password": true}); err != nil {
+		return err
+	}
+	return t.tokens.RevokeAllForUser(context.Background(), userID)
+}
+
+// DeleteUser: Deletes a user, moving their tasks to reassignTo or purging them when it is nil
+func (t *TaskServices) DeleteUser(actor models.Actor, userID uint, reassignTo *uint) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if userID == actor.UserID {
+		return errors.New("admins cannot delete their own account")
+	}
+	if reassignTo != nil {
+		if *reassignTo == userID {
+			return errors.New("cannot reassign tasks to the deleted user")
+		}
+		if _, err := t.Repo.FindUserByID(*reassignTo); err != nil {
+			return fmt.Errorf("reassign target: %w", err)
+		}
+	}
+	if err := t.Repo.DeleteUser(userID, reassignTo, actor.UserID); err != nil {
+		return err
+	}
+	if reassignTo == nil {
+		t.sweepAttachments()
+	}
+
+	ctx := context.Background()
+	if err := t.tokens.RevokeAllForUser(ctx, userID); err != nil {
+		t.Logger.Println("failed to revoke sessions of deleted user:", err)
+	}
+	go func() {
+		t.invalidateTaskCache(userID, 0)
+		if reassignTo != nil {
+			t.invalidateTaskCache(*reassignTo, 0)
+		}
+		if delErr := t.redis.DeleteByPattern("task:id=*"); delErr != nil {
+			t.Logger.Println("Redis delete error:", delErr)
+		}
+	}()
+	return nil
+}
+
+// UnlockUser: Clears the failed login counters and lockout of a user
+func (t *TaskServices) UnlockUser(actor models.Actor, userID uint) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	t.guard.Unlock(user.Username)
+	return nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+const (
+	// apiKeyPrefix marks our keys so secret scanners can recognise them
+	apiKeyPrefix = "tm_"
+	// defaultAPIKeyTTL applies when no expiry is requested, maxAPIKeyTTL caps it
+	defaultAPIKeyTTL = 90 * 24 * time.Hour
+	maxAPIKeyTTL     = 365 * 24 * time.Hour
+)
+
+// ErrInvalidAPIKey is returned for unknown, expired or revoked keys alike
+var ErrInvalidAPIKey = errors.New("invalid or expired api key")
+
+// CreateAPIKey: Mints a key for the user. The plain key is only part of this response.
+func (t *TaskServices) CreateAPIKey(userID uint, name string, scopes []string, ttl time.Duration) (*models.CreatedAPIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("api key name is required")
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !models.IsValidScope(scope) {
+			return nil, fmt.Errorf("invalid scope %q", scope)
+		}
+	}
+	if ttl <= 0 {
+		ttl = defaultAPIKeyTTL
+	}
+	if ttl > maxAPIKeyTTL {
+		return nil, errors.New("api keys can be valid for at most 365 days")
+	}
+
+	prefix, secret, err := newAPIKeyParts()
+	if err != nil {
+		return nil, err
+	}
+	raw := apiKeyPrefix + prefix + "_" + secret
+
+	key := models.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   hashAPIKey(raw),
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := t.Repo.CreateAPIKey(&key); err != nil {
+		return nil, err
+	}
+	return &models.CreatedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+// ListAPIKeys: Returns the user's keys without their secrets
+func (t *TaskServices) ListAPIKeys(userID uint) ([]models.APIKey, error) {
+	return t.Repo.ListAPIKeys(userID)
+}
+
+// RevokeAPIKey: Revokes one of the user's keys
+func (t *TaskServices) RevokeAPIKey(userID, id uint) error {
+	return t.Repo.RevokeAPIKey(userID, id)
+}
+
+// AuthenticateAPIKey: Resolves an X-API-Key header to its owner and records the use
+func (t *TaskServices) AuthenticateAPIKey(raw string) (*models.Users, *models.APIKey, error) {
+	prefix, ok := parseAPIKeyPrefix(raw)
+	if !ok {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	key, err := t.Repo.GetAPIKeyByPrefix(prefix)
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(raw)), []byte(key.KeyHash)) != 1 ||
+		key.RevokedAt != nil || now.After(key.ExpiresAt) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := t.Repo.FindUserByID(key.UserID)
+	if err != nil || user.Disabled {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	go func(id uint) {
+		if err := t.Repo.TouchAPIKey(id, now); err != nil {
+			t.Logger.Println("failed to record api key use:", err)
+		}
+	}(key.ID)
+	return user, key, nil
+}
+
+// newAPIKeyParts returns a random lookup prefix and secret
+func newAPIKeyParts() (string, string, error) {
+	b := make([]byte, 36)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(b[:4]), hex.EncodeToString(b[4:]), nil
+}
+
+// parseAPIKeyPrefix extracts the lookup prefix from tm_<prefix>_<secret>
+func parseAPIKeyPrefix(raw string) (string, bool) {
+	if !strings.HasPrefix(raw, apiKeyPrefix) {
+		return "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(raw, apiKeyPrefix), "_", 2)
+	if len(parts) != 2 || len(parts[0]) != 8 || parts[1] == "" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// hashAPIKey hashes a key for storage. Keys carry 256 random bits, so unlike
+// passwords they do not need a slow hash.
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
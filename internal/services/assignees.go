@@ -0,0 +1,57 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// Errors returned when a user cannot be assigned
+var (
+	ErrAssigneeCannotSeeTask = errors.New("assignee is not allowed to see the task")
+	ErrAssigneeDisabled      = errors.New("assignee is disabled")
+)
+
+// UpdateAssignees: Assigns and unassigns users on a task the actor may modify and
+// returns the task with its new assignees. Every new assignee has to exist, be
+// enabled and be allowed to read the task; assignment grants no access by itself.
+func (t *TaskServices) UpdateAssignees(actor models.Actor, taskID uint, assign, unassign []uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userID := range assign {
+		user, err := t.Repo.FindUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if user.Disabled {
+			return nil, fmt.Errorf("%w: user %d", ErrAssigneeDisabled, userID)
+		}
+		allowed, err := t.canAccessTask(models.Actor{UserID: user.ID, Role: user.Role}, ActionReadTask, task)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: user %d", ErrAssigneeCannotSeeTask, userID)
+		}
+	}
+
+	if err := t.Repo.UpdateTaskAssignees(taskID, assign, unassign, actor.UserID); err != nil {
+		return nil, err
+	}
+	// Assignee filters of every affected user's lists change
+	for _, userID := range append(append([]uint{}, assign...), unassign...) {
+		go t.invalidateTaskCache(userID, 0)
+	}
+	t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+
+	updated, err := t.reloadTask(actor.UserID, task, "assignees", func(task *models.Task) []uint { return task.AssigneeIDs })
+	if err != nil {
+		return nil, err
+	}
+	t.notifyAssignees(actor.UserID, updated, task.AssigneeIDs, updated.AssigneeIDs)
+	return updated, nil
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/blobstore"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+)
+
+// AttachmentPolicy limits uploads. The content type is sniffed from the file itself,
+// whatever the client claims. A UserQuota of 0 means no quota.
+type AttachmentPolicy struct {
+	MaxSize      int64
+	AllowedTypes []string
+	UserQuota    int64
+}
+
+// DefaultAttachmentPolicy allows images, PDFs and plain text up to 10 MiB, and
+// 100 MiB of uploads per user
+var DefaultAttachmentPolicy = AttachmentPolicy{
+	MaxSize:      10 << 20,
+	AllowedTypes: []string{"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain"},
+	UserQuota:    100 << 20,
+}
+
+// Errors returned by the attachment endpoints
+var (
+	ErrAttachmentsDisabled = errors.New("attachments are not configured")
+	ErrAttachmentSize      = errors.New("attachment size is not allowed")
+	ErrAttachmentType      = errors.New("attachment type is not allowed")
+)
+
+// maxFileNameLength is the longest file name kept for an attachment
+const maxFileNameLength = 255
+
+// WithAttachments sets the blob store uploads are kept in and the limits they are
+// checked against. Without it the attachment endpoints are disabled.
+func WithAttachments(store blobstore.BlobStore, policy AttachmentPolicy) Option {
+	return func(t *TaskServices) {
+		t.blobs = store
+		t.attachments = policy
+	}
+}
+
+// allowedType reports whether the media type of a sniffed content type is allowed
+func (p AttachmentPolicy) allowedType(contentType string) bool {
+	media, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range p.AllowedTypes {
+		if strings.EqualFold(media, strings.TrimSpace(allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFileName keeps the base name of an uploaded file
+func cleanFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > maxFileNameLength {
+		name = name[:maxFileNameLength]
+	}
+	return name
+}
+
+// taskAttachment loads an attachment of a task the actor may read
+func (t *TaskServices) taskAttachment(actor models.Actor, taskID, attachmentID uint) (*models.Task, *models.Attachment, error) {
+	if t.blobs == nil {
+		return nil, nil, ErrAttachmentsDisabled
+	}
+	task, err := t.authorizedTask(actor, ActionReadTask, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachment, err := t.Repo.GetAttachmentByID(attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, nil, models.ErrAttachmentNotFound
+	}
+	return task, attachment, nil
+}
+
+// UploadAttachment: Stores a file of size bytes read from r on a task the actor may modify
+func (t *TaskServices) UploadAttachment(actor models.Actor, taskID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error) {
+	if t.blobs == nil {
+		return nil, ErrAttachmentsDisabled
+	}
+	if _, err := t.authorizedTask(actor, ActionUpdateTask, taskID); err != nil {
+		return nil, err
+	}
+	if size <= 0 || size > t.attachments.MaxSize {
+		return nil, fmt.Errorf("%w, files must be 1 to %d bytes", ErrAttachmentSize, t.attachments.MaxSize)
+	}
+
+	// Sniff the content type from the first bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !t.attachments.allowedType(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentType, contentType)
+	}
+
+	quota := t.attachments.UserQuota
+	if quota > 0 {
+		used, err := t.Repo.GetAttachmentUsage(actor.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if used+size > quota {
+			return nil, fmt.Errorf("%w, %d of %d bytes used", models.ErrStorageQuotaExceeded, used, quota)
+		}
+	}
+
+	attachment := &models.Attachment{
+		TaskID:      taskID,
+		UploaderID:  actor.UserID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  fmt.Sprintf("tasks/%d/%s", taskID, utility.NewTokenID()),
+	}
+	ctx := context.Background()
+	if err := t.blobs.Put(ctx, attachment.StorageKey, io.MultiReader(bytes.NewReader(head), r), size, contentType); err != nil {
+		return nil, err
+	}
+	if err := t.Repo.CreateAttachment(attachment, quota); err != nil {
+		if delErr := t.blobs.Delete(ctx, attachment.StorageKey); delErr != nil {
+			t.Logger.Println("failed to delete blob of rejected attachment:", delErr)
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// ListAttachments: Lists the attachments of a task the actor may read
+func (t *TaskServices) ListAttachments(actor models.Actor, taskID uint) ([]models.Attachment, error) {
+	if t.blobs == nil {
+		return nil, ErrAttachmentsDisabled
+	}
+	if _, err := t.authorizedTask(actor, ActionReadTask, taskID); err != nil {
+		return nil, err
+	}
+	return t.Repo.GetAttachments(taskID)
+}
+
+// OpenAttachment: Opens the contents of an attachment of a task the actor may read,
+// the caller closes the reader
+func (t *TaskServices) OpenAttachment(actor models.Actor, taskID, attachmentID uint) (*models.Attachment, io.ReadCloser, error) {
+	_, attachment, err := t.taskAttachment(actor, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := t.blobs.Get(context.Background(), attachment.StorageKey)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil, models.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, body, nil
+}
+
+// DeleteAttachment: Deletes an attachment. Uploaders delete their own files, others
+// need to be able to modify the task.
+func (t *TaskServices) DeleteAttachment(actor models.Actor, taskID, attachmentID uint) error {
+	task, attachment, err := t.taskAttachment(actor, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploaderID != actor.UserID {
+		allowed, err := t.canAccessTask(actor, ActionUpdateTask, task)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return models.ErrForbidden
+		}
+	}
+	if err := t.Repo.DeleteAttachment(attachment.ID); err != nil {
+		return err
+	}
+	if err := t.blobs.Delete(context.Background(), attachment.StorageKey); err != nil {
+		t.Logger.Printf("failed to delete blob %s: %v", attachment.StorageKey, err)
+	}
+	return nil
+}
+
+// sweepAttachments deletes the attachments and blobs of tasks that were purged
+func (t *TaskServices) sweepAttachments() {
+	if t.blobs == nil {
+		return
+	}
+	keys, err := t.Repo.DeleteOrphanedAttachments()
+	if err != nil {
+		t.Logger.Println("failed to delete attachments of purged tasks:", err)
+		return
+	}
+	for _, key := range keys {
+		if err := t.blobs.Delete(context.Background(), key); err != nil {
+			t.Logger.Printf("failed to delete blob %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// maxCommentLength is the longest comment body in characters
+const maxCommentLength = 10000
+
+// ErrInvalidComment is returned for empty or too long comment bodies
+var ErrInvalidComment = fmt.Errorf("comment body must be 1-%d characters", maxCommentLength)
+
+// validateCommentBody trims a Markdown comment body and checks its length
+func validateCommentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxCommentLength {
+		return "", ErrInvalidComment
+	}
+	return body, nil
+}
+
+// taskComment loads a comment of a task the actor may read
+func (t *TaskServices) taskComment(actor models.Actor, taskID, commentID uint) (*models.Task, *models.Comment, error) {
+	task, err := t.authorizedTask(actor, ActionReadTask, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := t.Repo.GetCommentByID(commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comment.TaskID != taskID {
+		return nil, nil, models.ErrCommentNotFound
+	}
+	return task, comment, nil
+}
+
+// AddComment: Adds a comment to the thread of a task the actor may read
+func (t *TaskServices) AddComment(actor models.Actor, taskID uint, body string) (*models.Comment, error) {
+	task, err := t.authorizedTask(actor, ActionReadTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	comment := &models.Comment{TaskID: taskID, AuthorID: actor.UserID, Body: body}
+	if err := t.Repo.CreateComment(comment); err != nil {
+		return nil, err
+	}
+	t.notifyMentions(actor.UserID, task, &comment.ID, mentions(body))
+	// The comment count of the task changed
+	go t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return comment, nil
+}
+
+// ListComments: Pages through the thread of a task the actor may read, oldest first
+func (t *TaskServices) ListComments(actor models.Actor, taskID uint, page, limit int) ([]models.Comment, int64, error) {
+	if _, err := t.authorizedTask(actor, ActionReadTask, taskID); err != nil {
+		return nil, 0, err
+	}
+	return t.Repo.GetComments(taskID, page, limit)
+}
+
+// UpdateComment: Changes the body of the actor's own comment, keeping the old body
+// in the comment's edits
+func (t *TaskServices) UpdateComment(actor models.Actor, taskID, commentID uint, body string) (*models.Comment, error) {
+	task, comment, err := t.taskComment(actor, taskID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.UserID {
+		return nil, models.ErrForbidden
+	}
+	body, err = validateCommentBody(body)
+	if err != nil {
+		return nil, err
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+	edit := &models.CommentEdit{CommentID: comment.ID, EditorID: actor.UserID, Body: comment.Body}
+	added := newMentions(comment.Body, body)
+	now := time.Now()
+	comment.Body = body
+	comment.EditedAt = &now
+	comment.UpdatedAt = now
+	if err := t.Repo.UpdateComment(comment, edit); err != nil {
+		return nil, err
+	}
+	t.notifyMentions(actor.UserID, task, &comment.ID, added)
+	return comment, nil
+}
+
+// DeleteComment: Deletes a comment. Authors delete their own comments, admins
+// moderate any comment.
+func (t *TaskServices) DeleteComment(actor models.Actor, taskID, commentID uint) error {
+	task, comment, err := t.taskComment(actor, taskID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.UserID && actor.Role != models.RoleAdmin {
+		return models.ErrForbidden
+	}
+	if err := t.Repo.DeleteComment(comment.ID); err != nil {
+		return err
+	}
+	go t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return nil
+}
+
+// GetCommentEdits: Lists the earlier bodies of a comment on a task the actor may read
+func (t *TaskServices) GetCommentEdits(actor models.Actor, taskID, commentID uint) ([]models.CommentEdit, error) {
+	if _, _, err := t.taskComment(actor, taskID, commentID); err != nil {
+		return nil, err
+	}
+	return t.Repo.GetCommentEdits(commentID)
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// ErrTaskBlocked is returned when a task with open dependencies is moved to a doing
+// status without force
+var ErrTaskBlocked = errors.New("the task is blocked by open tasks")
+
+// checkNotBlocked rejects starting a task while tasks it depends on are open
+func (t *TaskServices) checkNotBlocked(id uint) error {
+	blockers, err := t.Repo.GetOpenBlockerIDs(id)
+	if err != nil {
+		return err
+	}
+	if len(blockers) > 0 {
+		return fmt.Errorf("%w %v, pass force to start it anyway", ErrTaskBlocked, blockers)
+	}
+	return nil
+}
+
+// invalidateDependents clears the cached tasks waiting on the task, their blocked
+// flag follows its status. They may belong to anyone, so all cached lists are cleared.
+func (t *TaskServices) invalidateDependents(id uint) {
+	if t.redis == nil {
+		return
+	}
+	dependents, err := t.Repo.GetDependentTaskIDs(id)
+	if err != nil {
+		t.Logger.Println("failed to load dependent tasks:", err)
+		return
+	}
+	if len(dependents) > 0 {
+		t.invalidateSharedTasks(dependents)
+	}
+}
+
+// AddDependency: Makes a task the actor may modify wait on a task the actor can see
+func (t *TaskServices) AddDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := t.authorizedTask(actor, ActionReadTask, dependsOnID); err != nil {
+		return nil, err
+	}
+	dep := &models.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID, CreatedBy: actor.UserID}
+	if err := t.Repo.AddTaskDependency(dep); err != nil {
+		return nil, err
+	}
+	t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return t.reloadTask(actor.UserID, task, "depends_on", func(task *models.Task) []uint { return task.DependsOn })
+}
+
+// RemoveDependency: Removes a dependency of a task the actor may modify
+func (t *TaskServices) RemoveDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Repo.RemoveTaskDependency(taskID, dependsOnID); err != nil {
+		return nil, err
+	}
+	t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return t.reloadTask(actor.UserID, task, "depends_on", func(task *models.Task) []uint { return task.DependsOn })
+}
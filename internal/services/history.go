@@ -0,0 +1,148 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// historyFields returns the audited fields of a task with JSON friendly values
+func historyFields(task *models.Task) []models.FieldChange {
+	var dueDate, reminders interface{}
+	if task.DueDate != nil {
+		dueDate = task.DueDate.UTC().Format(time.RFC3339)
+	}
+	if len(task.ReminderMinutes) > 0 {
+		reminders = task.ReminderMinutes
+	}
+	return []models.FieldChange{
+		{Field: "title", After: task.Title},
+		{Field: "description", After: task.Description},
+		{Field: "status", After: string(task.Status)},
+		{Field: "priority", After: string(task.Priority)},
+		{Field: "due_date", After: dueDate},
+		{Field: "reminder_minutes", After: reminders},
+		{Field: "recurrence", After: task.Recurrence},
+		{Field: "project_id", After: optionalID(task.ProjectID)},
+		{Field: "parent_id", After: optionalID(task.ParentID)},
+	}
+}
+
+// optionalID turns an optional id into an id or nil
+func optionalID(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// taskDiff lists the audited fields that differ between two versions of a task.
+// A nil before lists every field that is set on after.
+func taskDiff(before, after *models.Task) []models.FieldChange {
+	changes := []models.FieldChange{}
+	afterFields := historyFields(after)
+	if before == nil {
+		for _, f := range afterFields {
+			if f.After != nil && f.After != "" {
+				changes = append(changes, f)
+			}
+		}
+		return changes
+	}
+	for i, f := range historyFields(before) {
+		if !reflect.DeepEqual(f.After, afterFields[i].After) {
+			changes = append(changes, models.FieldChange{Field: f.Field, Before: f.After, After: afterFields[i].After})
+		}
+	}
+	return changes
+}
+
+// idsChange records a change of a list of ids, such as the assignees of a task
+func idsChange(field string, before, after []uint) []models.FieldChange {
+	if before == nil {
+		before = []uint{}
+	}
+	if after == nil {
+		after = []uint{}
+	}
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	return []models.FieldChange{{Field: field, Before: before, After: after}}
+}
+
+// idsOfLabels lists the ids of the labels
+func idsOfLabels(labels []models.Label) []uint {
+	ids := make([]uint, len(labels))
+	for i, l := range labels {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+// recordHistory appends a history entry for the task and publishes it to webhook
+// subscribers. The change itself has already been stored, so a failure is logged
+// rather than returned.
+func (t *TaskServices) recordHistory(actorID uint, task *models.Task, action string, changes []models.FieldChange) {
+	if action == models.HistoryUpdated && len(changes) == 0 {
+		return
+	}
+	entry := &models.TaskHistory{
+		TaskID:    task.ID,
+		ActorID:   actorID,
+		Action:    action,
+		Changes:   changes,
+		OwnerID:   task.UserID,
+		ProjectID: task.ProjectID,
+	}
+	if err := t.Repo.CreateTaskHistory(entry); err != nil {
+		t.Logger.Printf("failed to record %s history of task %d: %v", action, task.ID, err)
+	}
+	t.publishHistory(actorID, task, action, changes)
+}
+
+// GetTaskHistory: Pages through the history of a task the actor may read, oldest
+// first. The history of deleted tasks stays readable for those who could read them.
+func (t *TaskServices) GetTaskHistory(actor models.Actor, id uint, page, limit int) ([]models.TaskHistory, int64, error) {
+	_, err := t.authorizedTask(actor, ActionReadTask, id)
+	if errors.Is(err, models.ErrTaskNotFound) {
+		err = t.checkDeletedTaskAccess(actor, id)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return t.Repo.GetTaskHistory(id, page, limit)
+}
+
+// checkDeletedTaskAccess checks read access to a deleted task against the owner
+// and project it had when it was deleted
+func (t *TaskServices) checkDeletedTaskAccess(actor models.Actor, id uint) error {
+	last, err := t.Repo.GetLastTaskHistory(id)
+	if err != nil {
+		return err
+	}
+	if last.Action != models.HistoryDeleted {
+		return models.ErrTaskNotFound
+	}
+	allowed, err := t.canAccessTask(actor, ActionReadTask, &models.Task{ID: id, UserID: last.OwnerID, ProjectID: last.ProjectID})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return models.ErrTaskNotFound
+	}
+	return nil
+}
+
+// reloadTask fetches a task after a change to one of its id lists and records the
+// change of that list
+func (t *TaskServices) reloadTask(actorID uint, before *models.Task, field string, ids func(*models.Task) []uint) (*models.Task, error) {
+	updated, err := t.Repo.GetTaskByID(before.ID)
+	if err != nil {
+		return nil, err
+	}
+	t.recordHistory(actorID, updated, models.HistoryUpdated, idsChange(field, ids(before), ids(updated)))
+	return updated, nil
+}
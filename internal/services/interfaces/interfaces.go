@@ -1,15 +1,115 @@
 package interfaces
 
-import "github.com/ratheeshkumar25/task-mgt/internal/models"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+)
 
 type TaskServiceInter interface {
 	//Service to handle the user
 	CreateUser(user *models.Users) error
-	LoginUser(username string, password string) (string, error)
+	LoginUser(username string, password string, clientIP string) (*utility.LoginResult, error)
+	CompleteMFALogin(mfaToken, code, clientIP string) (*utility.TokenPair, error)
+	RefreshToken(refreshToken string) (*utility.TokenPair, error)
+	Logout(claims *utility.UserClaim) error
+	LogoutAll(userID uint) error
+	VerifyTokenUser(claims *utility.UserClaim) (*models.Users, error)
+	//Two-factor authentication
+	BeginTOTPEnrollment(userID uint) (*models.TOTPEnrollment, error)
+	ConfirmTOTPEnrollment(userID uint, code string) ([]string, error)
+	DisableTOTP(userID uint, password, code string) error
+	//Personal API keys
+	CreateAPIKey(userID uint, name string, scopes []string, ttl time.Duration) (*models.CreatedAPIKey, error)
+	ListAPIKeys(userID uint) ([]models.APIKey, error)
+	RevokeAPIKey(userID, id uint) error
+	AuthenticateAPIKey(raw string) (*models.Users, *models.APIKey, error)
+	//Email verification and password reset
+	ResendVerificationEmail(userID uint) error
+	VerifyEmail(token string) error
+	ForgotPassword(username string) error
+	ResetPassword(token, newPassword string) error
+	//Admin user management
+	ListUsers(actor models.Actor, search string, page, limit int) ([]models.UserResponse, int64, error)
+	GetUserDetail(actor models.Actor, userID uint) (*models.UserDetail, error)
+	UpdateUserRole(actor models.Actor, userID uint, role models.Role, managerID *uint) error
+	SetUserDisabled(actor models.Actor, userID uint, disabled bool) error
+	ForcePasswordReset(actor models.Actor, userID uint) error
+	UnlockUser(actor models.Actor, userID uint) error
+	DeleteUser(actor models.Actor, userID uint, reassignTo *uint) error
+	//Projects
+	CreateProject(actor models.Actor, project *models.Project) error
+	ListProjects(actor models.Actor, includeArchived bool) ([]models.Project, error)
+	GetProject(actor models.Actor, id uint) (*models.Project, error)
+	UpdateProject(actor models.Actor, project *models.Project) error
+	DeleteProject(actor models.Actor, id uint) error
+	AddProjectMember(actor models.Actor, projectID, userID uint) error
+	RemoveProjectMember(actor models.Actor, projectID, userID uint) error
+	GetProjectTasks(actor models.Actor, projectID uint, filter models.TaskFilter) ([]models.Task, int64, error)
+	//Labels
+	CreateLabel(actor models.Actor, label *models.Label) error
+	ListLabels(actor models.Actor) ([]models.Label, error)
+	UpdateLabel(actor models.Actor, label *models.Label) error
+	DeleteLabel(actor models.Actor, id uint) error
+	AttachLabels(actor models.Actor, taskID uint, labelIDs []uint) (*models.Task, error)
+	DetachLabel(actor models.Actor, taskID, labelID uint) (*models.Task, error)
+	//Subtasks
+	GetSubtasks(actor models.Actor, id uint) ([]models.Task, *models.TaskProgress, error)
+	//Dependencies
+	AddDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error)
+	RemoveDependency(actor models.Actor, taskID, dependsOnID uint) (*models.Task, error)
+	//Workflow
+	GetWorkflow() (*models.Workflow, error)
+	UpdateWorkflow(actor models.Actor, workflow *models.Workflow) error
+	GetTaskTransitions(actor models.Actor, id uint) (*models.TaskTransitions, error)
+	//History
+	GetTaskHistory(actor models.Actor, id uint, page, limit int) ([]models.TaskHistory, int64, error)
+	//Comments
+	AddComment(actor models.Actor, taskID uint, body string) (*models.Comment, error)
+	ListComments(actor models.Actor, taskID uint, page, limit int) ([]models.Comment, int64, error)
+	UpdateComment(actor models.Actor, taskID, commentID uint, body string) (*models.Comment, error)
+	DeleteComment(actor models.Actor, taskID, commentID uint) error
+	GetCommentEdits(actor models.Actor, taskID, commentID uint) ([]models.CommentEdit, error)
+	//Attachments
+	UploadAttachment(actor models.Actor, taskID uint, fileName string, size int64, r io.Reader) (*models.Attachment, error)
+	ListAttachments(actor models.Actor, taskID uint) ([]models.Attachment, error)
+	OpenAttachment(actor models.Actor, taskID, attachmentID uint) (*models.Attachment, io.ReadCloser, error)
+	DeleteAttachment(actor models.Actor, taskID, attachmentID uint) error
+	//Notifications
+	ListNotifications(actor models.Actor, unreadOnly bool, page, limit int) ([]models.Notification, int64, int64, error)
+	MarkNotificationRead(actor models.Actor, id uint) error
+	MarkAllNotificationsRead(actor models.Actor) (int64, error)
+	GetNotificationPreferences(actor models.Actor) (map[string]bool, error)
+	UpdateNotificationPreferences(actor models.Actor, prefs map[string]bool) (map[string]bool, error)
+	//Recurrence
+	PreviewRecurrence(actor models.Actor, id uint, n int) ([]time.Time, error)
+	StopRecurrence(actor models.Actor, id uint) (*models.Task, error)
+	//Reminders
+	SendDueReminders(ctx context.Context) (int, error)
+	//Webhooks
+	CreateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.CreatedWebhook, error)
+	ListWebhooks(actor models.Actor) ([]models.WebhookSubscription, error)
+	GetWebhook(actor models.Actor, id uint) (*models.WebhookSubscription, error)
+	UpdateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	DeleteWebhook(actor models.Actor, id uint) error
+	ListWebhookDeliveries(actor models.Actor, id uint, page, limit int) ([]models.WebhookDelivery, int64, error)
+	RedeliverWebhook(actor models.Actor, id, deliveryID uint) (*models.WebhookDelivery, error)
+	DeliverWebhooks(ctx context.Context) (int, error)
+	//Trash
+	GetTrash(actor models.Actor, page, limit int) ([]models.TrashedTask, int64, error)
+	RestoreTask(actor models.Actor, id uint) (*models.Task, error)
+	PurgeTask(actor models.Actor, id uint) error
+	EmptyTrash(actor models.Actor) (int64, error)
+	PurgeExpiredTrash() (int64, error)
 	//Service to handle the tasks
+	//Every task method is checked against the actor's role
 	CreateTask(task *models.Task) error
-	GetAllTasks(status, dueDateAfter, sortBy, sortOrder string, page, limit int) ([]models.Task, int64, error)
-	GetTaskByID(id uint) (*models.Task, error)
-	UpdateTask(task *models.Task) error
-	DeleteTask(id uint) error
+	GetAllTasks(actor models.Actor, filter models.TaskFilter) ([]models.Task, int64, error)
+	GetTaskByID(actor models.Actor, id uint) (*models.Task, error)
+	UpdateTask(actor models.Actor, task *models.Task, force bool) error
+	DeleteTask(actor models.Actor, id uint) error
+	UpdateAssignees(actor models.Actor, taskID uint, assign, unassign []uint) (*models.Task, error)
 }
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// ErrLabelExists is returned when the user already has a label with the name
+var ErrLabelExists = errors.New("a label with this name already exists")
+
+const (
+	defaultLabelColor  = "#808080"
+	maxLabelNameLength = 50
+)
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// validateLabel normalises the name and colour of a label
+func validateLabel(label *models.Label) error {
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Name == "" || len(label.Name) > maxLabelNameLength || strings.Contains(label.Name, ",") {
+		return errors.New("label name must be 1-50 characters without commas")
+	}
+	if label.Color == "" {
+		label.Color = defaultLabelColor
+	}
+	if !labelColorPattern.MatchString(label.Color) {
+		return errors.New("label color must look like #1a2b3c")
+	}
+	label.Color = strings.ToLower(label.Color)
+	return nil
+}
+
+// ownedLabel loads a label of the actor, admins may use any label
+func (t *TaskServices) ownedLabel(actor models.Actor, id uint) (*models.Label, error) {
+	label, err := t.Repo.GetLabelByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if label.UserID != actor.UserID && actor.Role != models.RoleAdmin {
+		return nil, models.ErrLabelNotFound
+	}
+	return label, nil
+}
+
+// checkLabelName rejects a name the label's owner already uses on another label
+func (t *TaskServices) checkLabelName(label *models.Label) error {
+	existing, err := t.Repo.FindLabelByName(label.UserID, label.Name)
+	if errors.Is(err, models.ErrLabelNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != label.ID {
+		return ErrLabelExists
+	}
+	return nil
+}
+
+// CreateLabel: Creates a label owned by the actor
+func (t *TaskServices) CreateLabel(actor models.Actor, label *models.Label) error {
+	label.ID = 0
+	label.UserID = actor.UserID
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	if err := t.checkLabelName(label); err != nil {
+		return err
+	}
+	return t.Repo.CreateLabel(label)
+}
+
+// ListLabels: Lists the actor's labels
+func (t *TaskServices) ListLabels(actor models.Actor) ([]models.Label, error) {
+	return t.Repo.ListLabels(actor.UserID)
+}
+
+// UpdateLabel: Renames or recolours a label of the actor
+func (t *TaskServices) UpdateLabel(actor models.Actor, label *models.Label) error {
+	existing, err := t.ownedLabel(actor, label.ID)
+	if err != nil {
+		return err
+	}
+	label.UserID = existing.UserID
+	label.CreatedAt = existing.CreatedAt
+	if err := validateLabel(label); err != nil {
+		return err
+	}
+	if err := t.checkLabelName(label); err != nil {
+		return err
+	}
+	taskIDs, err := t.Repo.GetLabelTaskIDs(label.ID)
+	if err != nil {
+		return err
+	}
+	if err := t.Repo.UpdateLabel(label); err != nil {
+		return err
+	}
+	go t.invalidateSharedTasks(taskIDs)
+	return nil
+}
+
+// DeleteLabel: Deletes a label of the actor and detaches it from every task
+func (t *TaskServices) DeleteLabel(actor models.Actor, id uint) error {
+	if _, err := t.ownedLabel(actor, id); err != nil {
+		return err
+	}
+	taskIDs, err := t.Repo.GetLabelTaskIDs(id)
+	if err != nil {
+		return err
+	}
+	if err := t.Repo.DeleteLabel(id); err != nil {
+		return err
+	}
+	go t.invalidateSharedTasks(taskIDs)
+	return nil
+}
+
+// AttachLabels: Puts the actor's labels on a task the actor may modify
+func (t *TaskServices) AttachLabels(actor models.Actor, taskID uint, labelIDs []uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range labelIDs {
+		if _, err := t.ownedLabel(actor, id); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.Repo.AttachLabels(taskID, labelIDs); err != nil {
+		return nil, err
+	}
+	t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return t.reloadTask(actor.UserID, task, "labels", func(task *models.Task) []uint { return idsOfLabels(task.Labels) })
+}
+
+// DetachLabel: Takes any label off a task the actor may modify
+func (t *TaskServices) DetachLabel(actor models.Actor, taskID, labelID uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Repo.DetachLabel(taskID, labelID); err != nil {
+		return nil, err
+	}
+	t.invalidateTaskCache(task.UserID, taskID, task.ProjectID)
+	return t.reloadTask(actor.UserID, task, "labels", func(task *models.Task) []uint { return idsOfLabels(task.Labels) })
+}
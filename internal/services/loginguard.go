@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	redis "github.com/go-redis/redis/v8"
+)
+
+// LoginPolicy controls how failed logins are throttled
+type LoginPolicy struct {
+	// DelayAfter failures in a row start progressive delays: 1s, 2s, 4s, ...
+	DelayAfter int
+	// MaxDelay caps a single progressive delay
+	MaxDelay time.Duration
+	// MaxFailures per username lock the account for LockoutDuration
+	MaxFailures int
+	// MaxIPFailures is the much higher limit per client IP, which may be shared
+	MaxIPFailures   int
+	LockoutDuration time.Duration
+	// Window after which a failure is forgotten
+	Window time.Duration
+}
+
+// DefaultLoginPolicy is used unless WithLoginPolicy overrides it
+var DefaultLoginPolicy = LoginPolicy{
+	DelayAfter:      3,
+	MaxDelay:        time.Minute,
+	MaxFailures:     10,
+	MaxIPFailures:   50,
+	LockoutDuration: 15 * time.Minute,
+	Window:          15 * time.Minute,
+}
+
+// LoginThrottledError is returned while a username or IP has to wait before retrying
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed attempts, account temporarily locked, retry in %d seconds", retrySeconds(e.RetryAfter))
+	}
+	return fmt.Sprintf("too many failed attempts, retry in %d seconds", retrySeconds(e.RetryAfter))
+}
+
+func retrySeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// attemptStore keeps failure counters and blocks per key
+type attemptStore interface {
+	Incr(key string, window time.Duration) (int64, error)
+	Block(key string, d time.Duration) error
+	BlockedFor(key string) (time.Duration, error)
+	Reset(key string) error
+}
+
+// loginGuard tracks failed logins per username and per IP. Redis is used when
+// available so every replica shares the counters; when it is unreachable the
+// guard falls back to process memory rather than failing open.
+type loginGuard struct {
+	policy   LoginPolicy
+	primary  attemptStore
+	fallback attemptStore
+	logger   func(v ...interface{})
+}
+
+func newLoginGuard(client *redis.Client, policy LoginPolicy, logger func(v ...interface{})) *loginGuard {
+	g := &loginGuard{policy: policy, fallback: newMemoryAttemptStore(), logger: logger}
+	if client != nil {
+		g.primary = &redisAttemptStore{client: client}
+	}
+	return g
+}
+
+func usernameKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+func ipKey(ip string) string { return "ip:" + ip }
+
+// do runs op against redis, switching to the in-memory store on errors
+func (g *loginGuard) do(op func(attemptStore) error) {
+	if g.primary != nil {
+		err := op(g.primary)
+		if err == nil {
+			return
+		}
+		g.logger("login guard: redis unavailable, using in-memory counters:", err)
+	}
+	if err := op(g.fallback); err != nil {
+		g.logger("login guard:", err)
+	}
+}
+
+// Check returns a LoginThrottledError when username or ip must wait
+func (g *loginGuard) Check(username, ip string) error {
+	var wait time.Duration
+	for _, key := range []string{usernameKey(username), ipKey(ip)} {
+		g.do(func(s attemptStore) error {
+			d, err := s.BlockedFor(key)
+			if err == nil && d > wait {
+				wait = d
+			}
+			return err
+		})
+	}
+	if wait > 0 {
+		return &LoginThrottledError{RetryAfter: wait, Locked: wait > g.policy.MaxDelay}
+	}
+	return nil
+}
+
+// Fail records a failed attempt and blocks the username or ip when a limit is reached
+func (g *loginGuard) Fail(username, ip string) {
+	g.fail(usernameKey(username), g.policy.MaxFailures)
+	g.fail(ipKey(ip), g.policy.MaxIPFailures)
+}
+
+func (g *loginGuard) fail(key string, max int) {
+	g.do(func(s attemptStore) error {
+		n, err := s.Incr(key, g.policy.Window)
+		if err != nil {
+			return err
+		}
+		if d := g.delay(int(n), max); d > 0 {
+			return s.Block(key, d)
+		}
+		return nil
+	})
+}
+
+// delay is how long a key has to wait after its n-th failure in a row
+func (g *loginGuard) delay(n, max int) time.Duration {
+	if n >= max {
+		return g.policy.LockoutDuration
+	}
+	if n < g.policy.DelayAfter {
+		return 0
+	}
+	d := time.Second << uint(n-g.policy.DelayAfter)
+	if d > g.policy.MaxDelay || d <= 0 {
+		d = g.policy.MaxDelay
+	}
+	return d
+}
+
+// Succeed clears the username counters after a successful login
+func (g *loginGuard) Succeed(username string) {
+	g.Unlock(username)
+}
+
+// Unlock clears the failures and any lockout of a username
+func (g *loginGuard) Unlock(username string) {
+	key := usernameKey(username)
+	g.do(func(s attemptStore) error { return s.Reset(key) })
+	// Both stores may hold state if redis flapped
+	if g.primary != nil {
+		_ = g.fallback.Reset(key)
+	}
+}
+
+// redisAttemptStore shares counters between replicas
+type redisAttemptStore struct {
+	client *redis.Client
+}
+
+func (s *redisAttemptStore) Incr(key string, window time.Duration) (int64, error) {
+	ctx := context.Background()
+	pipe := s.client.TxPipeline()
+	incr := pipe.Incr(ctx, "login_fail:"+key)
+	pipe.Expire(ctx, "login_fail:"+key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (s *redisAttemptStore) Block(key string, d time.Duration) error {
+	return s.client.Set(context.Background(), "login_block:"+key, 1, d).Err()
+}
+
+func (s *redisAttemptStore) BlockedFor(key string) (time.Duration, error) {
+	d, err := s.client.PTTL(context.Background(), "login_block:"+key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, nil
+	}
+	return d, nil
+}
+
+func (s *redisAttemptStore) Reset(key string) error {
+	return s.client.Del(context.Background(), "login_fail:"+key, "login_block:"+key).Err()
+}
+
+// memoryStoreSweepSize is the number of counters that triggers a sweep of expired ones
+const memoryStoreSweepSize = 10000
+
+// memoryAttemptStore is the single-process fallback
+type memoryAttemptStore struct {
+	mu       sync.Mutex
+	failures map[string]memoryCounter
+	blocks   map[string]time.Time
+}
+
+type memoryCounter struct {
+	count   int64
+	expires time.Time
+}
+
+func newMemoryAttemptStore() *memoryAttemptStore {
+	return &memoryAttemptStore{
+		failures: make(map[string]memoryCounter),
+		blocks:   make(map[string]time.Time),
+	}
+}
+
+func (s *memoryAttemptStore) Incr(key string, window time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if len(s.failures) > memoryStoreSweepSize {
+		s.sweep(now)
+	}
+	c := s.failures[key]
+	if now.After(c.expires) {
+		c.count = 0
+	}
+	c.count++
+	c.expires = now.Add(window)
+	s.failures[key] = c
+	return c.count, nil
+}
+
+// sweep drops expired entries so the maps do not grow without bound
+func (s *memoryAttemptStore) sweep(now time.Time) {
+	for key, c := range s.failures {
+		if now.After(c.expires) {
+			delete(s.failures, key)
+		}
+	}
+	for key, until := range s.blocks {
+		if now.After(until) {
+			delete(s.blocks, key)
+		}
+	}
+}
+
+func (s *memoryAttemptStore) Block(key string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks[key] = time.Now().Add(d)
+	return nil
+}
+
+func (s *memoryAttemptStore) BlockedFor(key string) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	until, ok := s.blocks[key]
+	if !ok {
+		return 0, nil
+	}
+	d := time.Until(until)
+	if d <= 0 {
+		delete(s.blocks, key)
+		return 0, nil
+	}
+	return d, nil
+}
+
+func (s *memoryAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.failures, key)
+	delete(s.blocks, key)
+	return nil
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// totpIssuer is shown next to the account in authenticator apps
+	totpIssuer = "Task Management"
+	// totpSkew accepts codes one step (30s) early or late
+	totpSkew = 1
+	// mfaChallengeTTL is how long the second login step may take
+	mfaChallengeTTL = 5 * time.Minute
+	// recoveryCodeCount codes are handed out when 2FA is enabled
+	recoveryCodeCount = 10
+)
+
+// Errors returned by the two-factor flows
+var (
+	ErrInvalidMFACode      = errors.New("invalid authentication code")
+	ErrInvalidMFAChallenge = errors.New("invalid or expired mfa challenge")
+	ErrTOTPAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrTOTPNotEnrolling    = errors.New("two-factor enrollment has not been started")
+)
+
+// mfaChallenge starts the second login step for a user with 2FA enabled. The challenge
+// token is bound to the password hash so a password change invalidates it.
+func (t *TaskServices) mfaChallenge(user *models.Users) (*utility.LoginResult, error) {
+	token, err := utility.GenerateActionToken(t.keys, utility.PurposeMFAChallenge, user.ID, user.PasswordHash, mfaChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &utility.LoginResult{
+		MFARequired:  true,
+		MFAToken:     token,
+		MFAExpiresIn: int64(mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// CompleteMFALogin: Answers an MFA challenge with a TOTP or recovery code and issues the tokens
+func (t *TaskServices) CompleteMFALogin(mfaToken, code, clientIP string) (*utility.TokenPair, error) {
+	claims, err := utility.ParseActionToken(t.keys, utility.PurposeMFAChallenge, mfaToken)
+	if err != nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+	user, err := t.Repo.FindUserByID(claims.UserID)
+	if err != nil || !user.TOTPEnabled || utility.Fingerprint(user.PasswordHash) != claims.Fingerprint {
+		return nil, ErrInvalidMFAChallenge
+	}
+	if user.Disabled {
+		return nil, ErrAccountDisabled
+	}
+
+	// Codes are guessable, so failures count towards the same lockout as passwords
+	if err := t.guard.Check(user.Username, clientIP); err != nil {
+		return nil, err
+	}
+	ok, err := t.verifySecondFactor(user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		t.guard.Fail(user.Username, clientIP)
+		return nil, ErrInvalidMFACode
+	}
+
+	fresh, err := t.tokens.MarkUsed(context.Background(), claims.Id, time.Unix(claims.ExpiresAt, 0))
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrInvalidMFAChallenge
+	}
+	t.guard.Succeed(user.Username)
+	return t.issueTokens(user, "")
+}
+
+// verifySecondFactor accepts a current TOTP code or an unused recovery code
+func (t *TaskServices) verifySecondFactor(user *models.Users, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if step, ok := utility.ValidateTOTP(user.TOTPSecret, code, time.Now(), totpSkew); ok {
+		if step <= user.TOTPLastStep {
+			return false, nil
+		}
+		if err := t.Repo.UpdateUserFlags(user.ID, map[string]interface{}{"totp_last_step": step}); err != nil {
+			return false, err
+		}
+		user.TOTPLastStep = step
+		return true, nil
+	}
+	if len(code) == 6 {
+		return false, nil
+	}
+	return t.Repo.UseRecoveryCode(user.ID, hashRecoveryCode(code))
+}
+
+// BeginTOTPEnrollment: Generates a new secret for the user. 2FA stays off until the
+// first code is confirmed with ConfirmTOTPEnrollment.
+func (t *TaskServices) BeginTOTPEnrollment(userID uint) (*models.TOTPEnrollment, error) {
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	secret, err := utility.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Repo.UpdateUserFlags(userID, map[string]interface{}{"totp_secret": secret}); err != nil {
+		return nil, err
+	}
+	return &models.TOTPEnrollment{
+		Secret:     secret,
+		OTPAuthURI: utility.TOTPURI(totpIssuer, user.Username, secret),
+	}, nil
+}
+
+// ConfirmTOTPEnrollment: Enables 2FA once a code from the new secret checks out and
+// returns the recovery codes. They are only ever shown here.
+func (t *TaskServices) ConfirmTOTPEnrollment(userID uint, code string) ([]string, error) {
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTOTPNotEnrolling
+	}
+	step, ok := utility.ValidateTOTP(user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	if err := t.Repo.UpdateUserFlags(userID, map[string]interface{}{
+		"totp_enabled":   true,
+		"totp_last_step": step,
+	}); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableTOTP: Turns 2FA off after checking both the password and a second factor
+func (t *TaskServices) DisableTOTP(userID uint, password, code string) error {
+	user, err := t.Repo.FindUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return ErrInvalidCredentials
+	}
+	ok, err := t.verifySecondFactor(user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	if err := t.Repo.UpdateUserFlags(userID, map[string]interface{}{
+		"totp_enabled":   false,
+		"totp_secret":    "",
+		"totp_last_step": 0,
+	}); err != nil {
+		return err
+	}
+	return t.Repo.ReplaceRecoveryCodes(userID, nil)
+}
+
+// generateRecoveryCodes returns n codes formatted as xxxxx-xxxxx and their hashes
+func generateRecoveryCodes(n int) ([]string, []string, error) {
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(b)
+		code := raw[:5] + "-" + raw[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normalises a recovery code and hashes it. The codes carry 40 random
+// bits, so a fast hash is enough and keeps lookups in the database simple.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// mentionRegex matches @username mentions. Usernames are email addresses, so a
+// mention reads like @jane@example.com.
+var mentionRegex = regexp.MustCompile(`(?:^|[\s(\[])@([a-zA-Z0-9._%+-]+@[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*\.[a-zA-Z]{2,})`)
+
+// mentions returns the lowercased usernames mentioned in text, each once
+func mentions(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		name := strings.ToLower(match[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// newMentions returns the usernames mentioned in text but not in before
+func newMentions(before, text string) []string {
+	old := make(map[string]bool)
+	for _, name := range mentions(before) {
+		old[name] = true
+	}
+	var added []string
+	for _, name := range mentions(text) {
+		if !old[name] {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+// notifyMentions notifies the users mentioned in text. Mentions of unknown and
+// disabled users and of users who cannot read the task are ignored.
+func (t *TaskServices) notifyMentions(actorID uint, task *models.Task, commentID *uint, usernames []string) {
+	if len(usernames) == 0 {
+		return
+	}
+	users, err := t.Repo.GetUsersByUsernames(usernames)
+	if err != nil {
+		t.Logger.Printf("failed to resolve mentions on task %d: %v", task.ID, err)
+		return
+	}
+	var recipients []uint
+	for _, user := range users {
+		if user.Disabled || user.ID == actorID {
+			continue
+		}
+		allowed, err := t.canAccessTask(models.Actor{UserID: user.ID, Role: user.Role}, ActionReadTask, task)
+		if err != nil {
+			t.Logger.Printf("failed to check mention of user %d on task %d: %v", user.ID, task.ID, err)
+			continue
+		}
+		if allowed {
+			recipients = append(recipients, user.ID)
+		}
+	}
+	where := "the description of"
+	if commentID != nil {
+		where = "a comment on"
+	}
+	t.notify(actorID, task, models.NotificationMention, commentID, fmt.Sprintf("you were mentioned in %s %q", where, task.Title), recipients)
+}
+
+// notify stores a notification for each recipient. The actor is never notified
+// of their own changes and users who turned the type off are skipped.
+func (t *TaskServices) notify(actorID uint, task *models.Task, kind string, commentID *uint, message string, recipients []uint) {
+	seen := map[uint]bool{actorID: true}
+	var userIDs []uint
+	for _, id := range recipients {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	muted, err := t.Repo.GetMutedUserIDs(kind, userIDs)
+	if err != nil {
+		t.Logger.Printf("failed to load %s notification preferences: %v", kind, err)
+		return
+	}
+	skip := make(map[uint]bool, len(muted))
+	for _, id := range muted {
+		skip[id] = true
+	}
+	var notifications []models.Notification
+	for _, id := range userIDs {
+		if skip[id] {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			UserID:    id,
+			Type:      kind,
+			TaskID:    task.ID,
+			CommentID: commentID,
+			ActorID:   actorID,
+			Message:   message,
+		})
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := t.Repo.CreateNotifications(notifications); err != nil {
+		t.Logger.Printf("failed to store %s notifications of task %d: %v", kind, task.ID, err)
+	}
+}
+
+// notifyAssignees notifies users that were assigned to or unassigned from a task
+func (t *TaskServices) notifyAssignees(actorID uint, task *models.Task, before, after []uint) {
+	was := make(map[uint]bool, len(before))
+	for _, id := range before {
+		was[id] = true
+	}
+	is := make(map[uint]bool, len(after))
+	var assigned, unassigned []uint
+	for _, id := range after {
+		is[id] = true
+		if !was[id] {
+			assigned = append(assigned, id)
+		}
+	}
+	for _, id := range before {
+		if !is[id] {
+			unassigned = append(unassigned, id)
+		}
+	}
+	t.notify(actorID, task, models.NotificationAssignment, nil, fmt.Sprintf("you were assigned to %q", task.Title), assigned)
+	t.notify(actorID, task, models.NotificationAssignment, nil, fmt.Sprintf("you were unassigned from %q", task.Title), unassigned)
+}
+
+// notifyStatus notifies the owner and the assignees of a task about a status change
+func (t *TaskServices) notifyStatus(actorID uint, task *models.Task, from, to models.TaskStatus) {
+	recipients := append([]uint{task.UserID}, task.AssigneeIDs...)
+	t.notify(actorID, task, models.NotificationStatus, nil, fmt.Sprintf("status of %q changed from %s to %s", task.Title, from, to), recipients)
+}
+
+// ListNotifications: Pages through the actor's notifications, newest first, along
+// with the number of unread ones
+func (t *TaskServices) ListNotifications(actor models.Actor, unreadOnly bool, page, limit int) ([]models.Notification, int64, int64, error) {
+	notifications, total, err := t.Repo.GetNotifications(actor.UserID, unreadOnly, page, limit)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	unread := total
+	if !unreadOnly {
+		if unread, err = t.Repo.CountUnreadNotifications(actor.UserID); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	return notifications, total, unread, nil
+}
+
+// MarkNotificationRead: Marks one of the actor's notifications as read
+func (t *TaskServices) MarkNotificationRead(actor models.Actor, id uint) error {
+	return t.Repo.MarkNotificationRead(actor.UserID, id)
+}
+
+// MarkAllNotificationsRead: Marks all of the actor's notifications as read and
+// returns how many were unread
+func (t *TaskServices) MarkAllNotificationsRead(actor models.Actor) (int64, error) {
+	return t.Repo.MarkAllNotificationsRead(actor.UserID)
+}
+
+// GetNotificationPreferences: Returns for every notification type whether the actor receives it
+func (t *TaskServices) GetNotificationPreferences(actor models.Actor) (map[string]bool, error) {
+	stored, err := t.Repo.GetNotificationPreferences(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	prefs := make(map[string]bool, len(models.NotificationTypes))
+	for _, kind := range models.NotificationTypes {
+		prefs[kind] = true
+	}
+	for _, pref := range stored {
+		if _, ok := prefs[pref.Type]; ok {
+			prefs[pref.Type] = pref.Enabled
+		}
+	}
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences: Turns notification types on or off for the actor.
+// Types left out keep their setting.
+func (t *TaskServices) UpdateNotificationPreferences(actor models.Actor, prefs map[string]bool) (map[string]bool, error) {
+	for kind := range prefs {
+		if !models.IsValidNotificationType(kind) {
+			return nil, fmt.Errorf("invalid notification type %q", kind)
+		}
+	}
+	if err := t.Repo.SetNotificationPreferences(actor.UserID, prefs); err != nil {
+		return nil, err
+	}
+	return t.GetNotificationPreferences(actor)
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// TaskAction is an operation a user can perform on a task
+type TaskAction string
+
+const (
+	ActionReadTask   TaskAction = "read"
+	ActionUpdateTask TaskAction = "update"
+	ActionDeleteTask TaskAction = "delete"
+)
+
+// accessScope is how far a role reaches for a given action
+type accessScope int
+
+const (
+	scopeOwn  accessScope = iota // tasks owned by the actor
+	scopeTeam                    // plus tasks owned by users reporting to the actor
+	scopeAll                     // every task
+)
+
+// taskPermissions is the permission matrix of the task endpoints.
+// Roles missing from the matrix fall back to scopeOwn.
+var taskPermissions = map[models.Role]map[TaskAction]accessScope{
+	models.RoleUser: {
+		ActionReadTask:   scopeOwn,
+		ActionUpdateTask: scopeOwn,
+		ActionDeleteTask: scopeOwn,
+	},
+	models.RoleManager: {
+		ActionReadTask:   scopeTeam,
+		ActionUpdateTask: scopeTeam,
+		ActionDeleteTask: scopeTeam,
+	},
+	models.RoleAdmin: {
+		ActionReadTask:   scopeAll,
+		ActionUpdateTask: scopeAll,
+		ActionDeleteTask: scopeAll,
+	},
+}
+
+func scopeFor(role models.Role, action TaskAction) accessScope {
+	if actions, ok := taskPermissions[role]; ok {
+		if scope, ok := actions[action]; ok {
+			return scope
+		}
+	}
+	return scopeOwn
+}
+
+// canAccessTask reports whether the actor may perform action on the task. Tasks on
+// a project are open to its members only, whoever owns them; admins reach every task.
+func (t *TaskServices) canAccessTask(actor models.Actor, action TaskAction, task *models.Task) (bool, error) {
+	if task.ProjectID != nil {
+		if scopeFor(actor.Role, action) == scopeAll {
+			return true, nil
+		}
+		return t.Repo.IsProjectMember(*task.ProjectID, actor.UserID)
+	}
+	return t.canAccessOwner(actor, action, task.UserID)
+}
+
+// canAccessOwner reports whether the actor may perform action on a personal task owned by ownerID
+func (t *TaskServices) canAccessOwner(actor models.Actor, action TaskAction, ownerID uint) (bool, error) {
+	if ownerID == actor.UserID {
+		return true, nil
+	}
+	switch scopeFor(actor.Role, action) {
+	case scopeAll:
+		return true, nil
+	case scopeTeam:
+		owner, err := t.Repo.FindUserByID(ownerID)
+		if errors.Is(err, models.ErrUserNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return owner.ManagerID != nil && *owner.ManagerID == actor.UserID, nil
+	default:
+		return false, nil
+	}
+}
+
+// ownerScope returns the owner IDs whose tasks the actor may list, nil meaning all
+func (t *TaskServices) ownerScope(actor models.Actor) ([]uint, error) {
+	switch scopeFor(actor.Role, ActionReadTask) {
+	case scopeAll:
+		return nil, nil
+	case scopeTeam:
+		team, err := t.Repo.GetTeamMemberIDs(actor.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return append([]uint{actor.UserID}, team...), nil
+	default:
+		return []uint{actor.UserID}, nil
+	}
+}
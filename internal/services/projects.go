@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// ErrProjectArchived is returned when tasks are added to an archived project
+var ErrProjectArchived = errors.New("project is archived")
+
+// visibleProject loads a project the actor may see, members and admins only.
+// Other projects are reported as ErrProjectNotFound.
+func (t *TaskServices) visibleProject(actor models.Actor, id uint) (*models.Project, error) {
+	project, err := t.Repo.GetProjectByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role == models.RoleAdmin {
+		return project, nil
+	}
+	for _, memberID := range project.MemberIDs {
+		if memberID == actor.UserID {
+			return project, nil
+		}
+	}
+	return nil, models.ErrProjectNotFound
+}
+
+// managedProject loads a project the actor may change, its owner and admins only
+func (t *TaskServices) managedProject(actor models.Actor, id uint) (*models.Project, error) {
+	project, err := t.visibleProject(actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.OwnerID != actor.UserID && actor.Role != models.RoleAdmin {
+		return nil, models.ErrForbidden
+	}
+	return project, nil
+}
+
+// checkTaskProject checks that a task may be put on the project
+func (t *TaskServices) checkTaskProject(actor models.Actor, projectID uint) error {
+	project, err := t.visibleProject(actor, projectID)
+	if err != nil {
+		return err
+	}
+	if project.Archived {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+// CreateProject: Creates a project owned by the actor
+func (t *TaskServices) CreateProject(actor models.Actor, project *models.Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return errors.New("project name is required")
+	}
+	project.ID = 0
+	project.OwnerID = actor.UserID
+	project.Archived = false
+	return t.Repo.CreateProject(project)
+}
+
+// ListProjects: Lists the projects the actor is a member of, every project for admins
+func (t *TaskServices) ListProjects(actor models.Actor, includeArchived bool) ([]models.Project, error) {
+	memberID := actor.UserID
+	if actor.Role == models.RoleAdmin {
+		memberID = 0
+	}
+	return t.Repo.ListProjects(memberID, includeArchived)
+}
+
+// GetProject: Retrieves a project the actor is a member of
+func (t *TaskServices) GetProject(actor models.Actor, id uint) (*models.Project, error) {
+	return t.visibleProject(actor, id)
+}
+
+// UpdateProject: Renames, describes or (un)archives a project, owner and admins only
+func (t *TaskServices) UpdateProject(actor models.Actor, project *models.Project) error {
+	existing, err := t.managedProject(actor, project.ID)
+	if err != nil {
+		return err
+	}
+	project.Name = strings.TrimSpace(project.Name)
+	if project.Name == "" {
+		return errors.New("project name is required")
+	}
+	project.UpdatedAt = time.Now()
+	if err := t.Repo.UpdateProject(project); err != nil {
+		return err
+	}
+	project.OwnerID = existing.OwnerID
+	project.MemberIDs = existing.MemberIDs
+	project.CreatedAt = existing.CreatedAt
+	return nil
+}
+
+// DeleteProject: Deletes a project, owner and admins only. Its tasks stay with
+// their owners, so their cached copies are cleared. Back on personal lists,
+// subtasks can only stay under tasks of the same owner, the others become top level.
+func (t *TaskServices) DeleteProject(actor models.Actor, id uint) error {
+	project, err := t.managedProject(actor, id)
+	if err != nil {
+		return err
+	}
+	tasks, _, err := t.Repo.GetFilteredTasks(models.TaskFilter{ProjectID: id})
+	if err != nil {
+		return err
+	}
+	owners := make(map[uint]uint, len(tasks))
+	for _, task := range tasks {
+		owners[task.ID] = task.UserID
+	}
+	var detach []uint
+	for _, task := range tasks {
+		if task.ParentID != nil && owners[*task.ParentID] != task.UserID {
+			detach = append(detach, task.ID)
+		}
+	}
+	if err := t.Repo.DeleteProject(id, detach); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		go t.invalidateTaskCache(task.UserID, task.ID)
+	}
+	for _, memberID := range project.MemberIDs {
+		go t.invalidateTaskCache(memberID, 0)
+	}
+	return nil
+}
+
+// AddProjectMember: Adds an existing user to a project, owner and admins only
+func (t *TaskServices) AddProjectMember(actor models.Actor, projectID, userID uint) error {
+	if _, err := t.managedProject(actor, projectID); err != nil {
+		return err
+	}
+	if _, err := t.Repo.FindUserByID(userID); err != nil {
+		return err
+	}
+	if err := t.Repo.AddProjectMember(projectID, userID); err != nil {
+		return err
+	}
+	// The project's tasks join the new member's lists
+	go t.invalidateTaskCache(userID, 0)
+	return nil
+}
+
+// RemoveProjectMember: Removes a member, owner and admins only; members may also
+// leave on their own. The owner cannot be removed.
+func (t *TaskServices) RemoveProjectMember(actor models.Actor, projectID, userID uint) error {
+	project, err := t.visibleProject(actor, projectID)
+	if err != nil {
+		return err
+	}
+	if userID != actor.UserID && project.OwnerID != actor.UserID && actor.Role != models.RoleAdmin {
+		return models.ErrForbidden
+	}
+	if userID == project.OwnerID {
+		return errors.New("the project owner cannot be removed")
+	}
+	if err := t.Repo.RemoveProjectMember(projectID, userID); err != nil {
+		return err
+	}
+	// The project's tasks drop out of the member's cached lists
+	go t.invalidateTaskCache(userID, 0)
+	return nil
+}
+
+// GetProjectTasks: Lists the tasks of a project the actor is a member of, whoever owns them
+func (t *TaskServices) GetProjectTasks(actor models.Actor, projectID uint, filter models.TaskFilter) ([]models.Task, int64, error) {
+	if _, err := t.visibleProject(actor, projectID); err != nil {
+		return nil, 0, err
+	}
+	filter.OwnerIDs = nil
+	filter.MemberID = 0
+	filter.ProjectID = projectID
+	return t.Repo.GetFilteredTasks(filter)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/recurrence"
+)
+
+// Errors returned for recurring tasks
+var (
+	ErrRecurrenceNeedsDueDate = errors.New("recurring tasks need a due date")
+	ErrNotRecurring           = errors.New("task is not recurring")
+)
+
+// checkRecurrence validates the recurrence rule of a task and stores it in its
+// canonical form
+func checkRecurrence(task *models.Task) error {
+	if task.Recurrence == "" {
+		return nil
+	}
+	if task.DueDate == nil {
+		return ErrRecurrenceNeedsDueDate
+	}
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return err
+	}
+	task.Recurrence = rule.String()
+	if task.Occurrence == 0 {
+		task.Occurrence = 1
+	}
+	return nil
+}
+
+// seriesID is the id of the first task of the series the task belongs to
+func seriesID(task *models.Task) uint {
+	if task.SeriesID != nil {
+		return *task.SeriesID
+	}
+	return task.ID
+}
+
+// nextOccurrences lists up to n due dates of the occurrences following the task,
+// stopping at the COUNT or UNTIL of its rule
+func nextOccurrences(task *models.Task, n int) ([]time.Time, error) {
+	if task.Recurrence == "" || task.DueDate == nil {
+		return nil, ErrNotRecurring
+	}
+	rule, err := recurrence.Parse(task.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Count > 0 && n > rule.Count-task.Occurrence {
+		n = rule.Count - task.Occurrence
+	}
+	if n <= 0 {
+		return []time.Time{}, nil
+	}
+	return rule.Next(*task.DueDate, n), nil
+}
+
+// createNextOccurrence puts the next task of a series on the owner's list when an
+// occurrence is completed. It starts over in the initial status with the due date
+// moved by the rule and keeps everything else, assignees and labels included.
+// Completing an occurrence again does not create another one.
+func (t *TaskServices) createNextOccurrence(actorID uint, task *models.Task, workflow *models.Workflow) {
+	dueDates, err := nextOccurrences(task, 1)
+	if err != nil {
+		t.Logger.Printf("failed to read recurrence of task %d: %v", task.ID, err)
+		return
+	}
+	if len(dueDates) == 0 {
+		return
+	}
+	series := seriesID(task)
+	now := time.Now()
+	next := &models.Task{
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          workflow.InitialStatus(),
+		Priority:        task.Priority,
+		DueDate:         &dueDates[0],
+		ReminderMinutes: task.ReminderMinutes,
+		Recurrence:      task.Recurrence,
+		SeriesID:        &series,
+		Occurrence:      task.Occurrence + 1,
+		UserID:          task.UserID,
+		ProjectID:       task.ProjectID,
+		ParentID:        task.ParentID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	created, err := t.Repo.CreateOccurrence(next)
+	if err != nil {
+		t.Logger.Printf("failed to create next occurrence of task %d: %v", task.ID, err)
+		return
+	}
+	if !created {
+		return
+	}
+	if len(task.AssigneeIDs) > 0 {
+		if err := t.Repo.UpdateTaskAssignees(next.ID, task.AssigneeIDs, nil, actorID); err != nil {
+			t.Logger.Printf("failed to copy assignees to task %d: %v", next.ID, err)
+		} else {
+			next.AssigneeIDs = task.AssigneeIDs
+		}
+	}
+	if labelIDs := idsOfLabels(task.Labels); len(labelIDs) > 0 {
+		if err := t.Repo.AttachLabels(next.ID, labelIDs); err != nil {
+			t.Logger.Printf("failed to copy labels to task %d: %v", next.ID, err)
+		}
+	}
+	t.recordHistory(actorID, next, models.HistoryCreated, taskDiff(nil, next))
+	t.notifyAssignees(actorID, next, nil, next.AssigneeIDs)
+	go t.invalidateTaskCache(next.UserID, 0, next.ProjectID)
+	go t.invalidateAncestors(next.ParentID)
+}
+
+// PreviewRecurrence: Lists the due dates of the next occurrences of a recurring
+// task the actor may read
+func (t *TaskServices) PreviewRecurrence(actor models.Actor, id uint, n int) ([]time.Time, error) {
+	task, err := t.authorizedTask(actor, ActionReadTask, id)
+	if err != nil {
+		return nil, err
+	}
+	return nextOccurrences(task, n)
+}
+
+// StopRecurrence: Ends the series of a recurring task the actor may modify. The
+// tasks of the series stay, but completing them creates no further occurrences.
+func (t *TaskServices) StopRecurrence(actor models.Actor, id uint) (*models.Task, error) {
+	task, err := t.authorizedTask(actor, ActionUpdateTask, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Recurrence == "" {
+		return nil, ErrNotRecurring
+	}
+	ids, err := t.Repo.StopTaskSeries(seriesID(task))
+	if err != nil {
+		return nil, err
+	}
+	t.recordHistory(actor.UserID, task, models.HistoryUpdated,
+		[]models.FieldChange{{Field: "recurrence", Before: task.Recurrence, After: ""}})
+	task.Recurrence = ""
+	go t.invalidateSharedTasks(ids)
+	go t.invalidateTaskCache(task.UserID, task.ID, task.ProjectID)
+	return task, nil
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/notifier"
+	inter "github.com/ratheeshkumar25/task-mgt/internal/services/interfaces"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+)
+
+// MaxReminderOffset is the earliest a reminder can be set before the due date
+const MaxReminderOffset = 30 * 24 * time.Hour
+
+// maxTaskReminders is the number of reminders a task can set
+const maxTaskReminders = 5
+
+// reminderLockKey is held by the replica sending reminders
+const reminderLockKey = "reminders:lock"
+
+// ErrInvalidReminder is returned for reminder offsets out of range
+var ErrInvalidReminder = fmt.Errorf("reminderMinutes takes up to %d reminders between 1 and %d minutes before the due date",
+	maxTaskReminders, int(MaxReminderOffset/time.Minute))
+
+// ReminderPolicy controls the due date reminders. Tasks without reminders of their
+// own get one Before each offset, and every task gets one when it becomes
+// overdue, unless it is overdue for longer than MaxOverdue.
+type ReminderPolicy struct {
+	Before     []time.Duration
+	MaxOverdue time.Duration
+	// InApp adds reminders to the users' notifications next to the notifiers
+	InApp bool
+	// LockTTL is how long a replica may hold the reminder lock
+	LockTTL time.Duration
+}
+
+// DefaultReminderPolicy reminds a day before the due date and once overdue, in-app only
+var DefaultReminderPolicy = ReminderPolicy{
+	Before:     []time.Duration{24 * time.Hour},
+	MaxOverdue: 24 * time.Hour,
+	InApp:      true,
+	LockTTL:    5 * time.Minute,
+}
+
+// WithReminders overrides DefaultReminderPolicy and sends reminders through the
+// notifiers as well
+func WithReminders(policy ReminderPolicy, notifiers ...notifier.Notifier) Option {
+	return func(t *TaskServices) {
+		t.reminderPolicy = policy
+		t.reminders = notifiers
+	}
+}
+
+// releaseLockScript deletes the lock only while it still holds our token, so a
+// run that outlived its TTL cannot drop another replica's lock
+var releaseLockScript = goredis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+// checkReminders validates the reminder offsets of a task and orders them
+// earliest first
+func checkReminders(task *models.Task) error {
+	if len(task.ReminderMinutes) > maxTaskReminders {
+		return ErrInvalidReminder
+	}
+	seen := make(map[int]bool, len(task.ReminderMinutes))
+	minutes := make([]int, 0, len(task.ReminderMinutes))
+	for _, m := range task.ReminderMinutes {
+		if m < 1 || time.Duration(m)*time.Minute > MaxReminderOffset {
+			return ErrInvalidReminder
+		}
+		if !seen[m] {
+			seen[m] = true
+			minutes = append(minutes, m)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(minutes)))
+	task.ReminderMinutes = minutes
+	return nil
+}
+
+// dueReminder picks the reminder of a task that is due now: the one closest to
+// the due date whose time has come. Earlier reminders that were missed, because
+// the due date was set late or the scheduler was down, are skipped.
+func (t *TaskServices) dueReminder(task *models.Task, now time.Time) (time.Duration, bool) {
+	offsets := t.reminderPolicy.Before
+	if len(task.ReminderMinutes) > 0 {
+		offsets = make([]time.Duration, len(task.ReminderMinutes))
+		for i, m := range task.ReminderMinutes {
+			offsets[i] = time.Duration(m) * time.Minute
+		}
+	}
+	// The overdue reminder is due at the due date itself
+	offsets = append([]time.Duration{0}, offsets...)
+	var due time.Duration
+	found := false
+	for _, offset := range offsets {
+		if task.DueDate.Add(-offset).After(now) {
+			continue
+		}
+		if !found || offset < due {
+			due, found = offset, true
+		}
+	}
+	return due, found
+}
+
+// lockReminders takes the reminder lock so only one replica sends reminders at a
+// time. Without redis there is a single replica and nothing to lock.
+func (t *TaskServices) lockReminders(ctx context.Context) (func(), bool, error) {
+	if t.redis == nil || t.redis.Client == nil {
+		return func() {}, true, nil
+	}
+	client := t.redis.Client
+	token := utility.NewTokenID()
+	locked, err := client.SetNX(ctx, reminderLockKey, token, t.reminderPolicy.LockTTL).Result()
+	if err != nil || !locked {
+		return nil, false, err
+	}
+	return func() {
+		if err := releaseLockScript.Run(context.Background(), client, []string{reminderLockKey}, token).Err(); err != nil {
+			t.Logger.Println("failed to release reminder lock:", err)
+		}
+	}, true, nil
+}
+
+// SendDueReminders: Sends the reminders of tasks that are due soon or overdue and
+// returns how many went out. Each reminder is claimed before it is sent, so it
+// goes out once however many replicas run the scheduler.
+func (t *TaskServices) SendDueReminders(ctx context.Context) (int, error) {
+	unlock, locked, err := t.lockReminders(ctx)
+	if err != nil || !locked {
+		return 0, err
+	}
+	defer unlock()
+
+	workflow, err := t.Repo.GetWorkflow()
+	if err != nil {
+		return 0, err
+	}
+	var done []models.TaskStatus
+	for _, s := range workflow.Statuses {
+		if s.Category == models.StatusCategoryDone {
+			done = append(done, s.Name)
+		}
+	}
+	now := time.Now()
+	tasks, err := t.Repo.GetDueTasks(now.Add(-t.reminderPolicy.MaxOverdue), now.Add(MaxReminderOffset), done)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for i := range tasks {
+		task := &tasks[i]
+		offset, ok := t.dueReminder(task, now)
+		if !ok {
+			continue
+		}
+		claim := &models.TaskReminder{
+			TaskID:        task.ID,
+			DueDate:       *task.DueDate,
+			OffsetMinutes: int(offset / time.Minute),
+			SentAt:        now,
+		}
+		claimed, err := t.Repo.ClaimReminder(claim)
+		if err != nil {
+			t.Logger.Printf("failed to claim reminder of task %d: %v", task.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if t.deliverReminder(ctx, task, offset) {
+			sent++
+			continue
+		}
+		// Nobody got it, try again on the next run
+		if err := t.Repo.ReleaseReminder(claim); err != nil {
+			t.Logger.Printf("failed to release reminder of task %d: %v", task.ID, err)
+		}
+	}
+	return sent, nil
+}
+
+// deliverReminder sends a reminder to the owner and the assignees of a task that
+// did not turn reminders off. It reports false when every delivery failed.
+func (t *TaskServices) deliverReminder(ctx context.Context, task *models.Task, offset time.Duration) bool {
+	recipients := uniqueIDs(append([]uint{task.UserID}, task.AssigneeIDs...))
+	muted, err := t.Repo.GetMutedUserIDs(models.NotificationReminder, recipients)
+	if err != nil {
+		t.Logger.Printf("failed to load reminder preferences of task %d: %v", task.ID, err)
+		return false
+	}
+	skip := make(map[uint]bool, len(muted))
+	for _, id := range muted {
+		skip[id] = true
+	}
+	var wanted []uint
+	for _, id := range recipients {
+		if !skip[id] {
+			wanted = append(wanted, id)
+		}
+	}
+	if len(wanted) == 0 {
+		return true
+	}
+	users, err := t.Repo.GetUsersByIDs(wanted)
+	if err != nil {
+		t.Logger.Printf("failed to load reminder recipients of task %d: %v", task.ID, err)
+		return false
+	}
+
+	attempted, delivered := 0, 0
+	for _, user := range users {
+		if user.Disabled {
+			continue
+		}
+		attempted++
+		reminder := notifier.Reminder{
+			UserID:    user.ID,
+			Username:  user.Username,
+			TaskID:    task.ID,
+			TaskTitle: task.Title,
+			DueDate:   *task.DueDate,
+			Before:    offset,
+		}
+		if err := t.reminders.Notify(ctx, reminder); err != nil {
+			t.Logger.Printf("failed to send reminder of task %d to user %d: %v", task.ID, user.ID, err)
+			continue
+		}
+		delivered++
+	}
+	return attempted == 0 || delivered > 0
+}
+
+// inAppReminders adds reminders to the notifications of their recipient
+func (t *TaskServices) inAppReminders(_ context.Context, r notifier.Reminder) error {
+	message := fmt.Sprintf("%q is due %s", r.TaskTitle, r.DueDate.UTC().Format(time.RFC1123))
+	if r.Overdue() {
+		message = fmt.Sprintf("%q is overdue", r.TaskTitle)
+	}
+	return t.Repo.CreateNotifications([]models.Notification{{
+		UserID:  r.UserID,
+		Type:    models.NotificationReminder,
+		TaskID:  r.TaskID,
+		Message: message,
+	}})
+}
+
+// uniqueIDs drops repeated and zero ids, keeping the order
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// RunReminders sends due reminders every interval until ctx is done
+func RunReminders(ctx context.Context, svc inter.TaskServiceInter, interval time.Duration, logger *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		sent, err := svc.SendDueReminders(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			logger.Println("failed to send due reminders:", err)
+		} else if sent > 0 {
+			logger.Printf("sent %d due date reminders", sent)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
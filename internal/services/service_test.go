@@ -1,15 +1,28 @@
 package services_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/ratheeshkumar25/task-mgt/internal/blobstore"
 	"github.com/ratheeshkumar25/task-mgt/internal/mocks"
 	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/notifier"
 	"github.com/ratheeshkumar25/task-mgt/internal/services"
+	"github.com/ratheeshkumar25/task-mgt/internal/webhook"
+	"github.com/ratheeshkumar25/task-mgt/utility"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -77,7 +90,1665 @@ func TestLoginUser_InvalidCredentials(t *testing.T) {
 
 	repoMock.EXPECT().GetUserByUsername("testuser").Return(user, nil)
 
-	token, err := service.LoginUser("testuser", "wrongpassword")
+	result, err := service.LoginUser("testuser", "wrongpassword", "127.0.0.1")
 	assert.Error(t, err)
-	assert.Empty(t, token)
+	assert.Nil(t, result)
+}
+
+func TestLoginUser_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &models.Users{ID: 1, Username: "testuser@example.com", PasswordHash: string(hash), Disabled: true}
+
+	repoMock.EXPECT().GetUserByUsername(user.Username).Return(user, nil)
+
+	result, err := service.LoginUser(user.Username, "password", "127.0.0.1")
+	assert.ErrorIs(t, err, services.ErrAccountDisabled)
+	assert.Nil(t, result)
+}
+
+func TestLoginUser_UnknownUserLooksLikeWrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetUserByUsername("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.LoginUser("nobody@example.com", "password", "127.0.0.1")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+}
+
+func TestLoginUser_Lockout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	policy := services.DefaultLoginPolicy
+	policy.DelayAfter = 100
+	policy.MaxFailures = 3
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithLoginPolicy(policy))
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &models.Users{ID: 1, Username: "testuser@example.com", PasswordHash: string(hash)}
+	repoMock.EXPECT().GetUserByUsername(user.Username).Return(user, nil).Times(4)
+
+	for i := 0; i < 3; i++ {
+		_, err := service.LoginUser(user.Username, "wrong", "127.0.0.1")
+		assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+	}
+
+	// Even the right password is refused while locked
+	_, err := service.LoginUser(user.Username, "password", "127.0.0.1")
+	var throttled *services.LoginThrottledError
+	assert.ErrorAs(t, err, &throttled)
+	assert.True(t, throttled.Locked)
+
+	// An admin unlock lifts the lockout
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(user, nil)
+	assert.NoError(t, service.UnlockUser(models.Actor{UserID: 9, Role: models.RoleAdmin}, 1))
+	_, err = service.LoginUser(user.Username, "wrong", "10.0.0.1")
+	assert.ErrorIs(t, err, services.ErrInvalidCredentials)
+}
+
+// Two-factor test cases
+func TestLoginUser_MFAChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	user := &models.Users{ID: 1, Username: "testuser@example.com", PasswordHash: string(hash), TOTPEnabled: true}
+	repoMock.EXPECT().GetUserByUsername(user.Username).Return(user, nil)
+
+	result, err := service.LoginUser(user.Username, "password", "127.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, result.MFARequired)
+	assert.NotEmpty(t, result.MFAToken)
+	assert.Nil(t, result.TokenPair)
+}
+
+func TestConfirmTOTPEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	secret, _ := utility.GenerateTOTPSecret()
+	code, _ := utility.TOTPCode(secret, utility.TOTPStep(time.Now()))
+
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(&models.Users{ID: 1, TOTPSecret: secret}, nil)
+	repoMock.EXPECT().ReplaceRecoveryCodes(uint(1), gomock.Len(10)).Return(nil)
+	repoMock.EXPECT().UpdateUserFlags(uint(1), gomock.Any()).Return(nil)
+
+	codes, err := service.ConfirmTOTPEnrollment(1, code)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+}
+
+// Refresh token test case
+func TestRefreshToken_RejectsAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	pair, err := utility.GenerateTokenPair(utility.NewHMACKeySet(""), "testuser@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	tokens, err := service.RefreshToken(pair.AccessToken)
+	assert.ErrorIs(t, err, services.ErrInvalidRefreshToken)
+	assert.Nil(t, tokens)
+}
+
+func TestVerifyTokenUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	claims := &utility.UserClaim{UserID: 1, Username: "testuser@example.com"}
+
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(&models.Users{ID: 1, Username: "testuser@example.com", Role: models.RoleAdmin}, nil)
+	user, err := service.VerifyTokenUser(claims)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleAdmin, user.Role)
+
+	var tokenErr *utility.TokenError
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(&models.Users{ID: 1, Username: "testuser@example.com", Disabled: true}, nil)
+	_, err = service.VerifyTokenUser(claims)
+	assert.ErrorAs(t, err, &tokenErr)
+	assert.Equal(t, utility.ErrCodeUserDisabled, tokenErr.Code)
+
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(nil, models.ErrUserNotFound)
+	_, err = service.VerifyTokenUser(claims)
+	assert.ErrorAs(t, err, &tokenErr)
+	assert.Equal(t, utility.ErrCodeUserNotFound, tokenErr.Code)
+
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(nil, errors.New("db down"))
+	_, err = service.VerifyTokenUser(claims)
+	assert.False(t, errors.As(err, &tokenErr))
+}
+
+// Task permission test cases
+func TestGetTaskByID_OtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 3}, nil)
+
+	task, err := service.GetTaskByID(models.Actor{UserID: 2, Role: models.RoleUser}, 1)
+	assert.ErrorIs(t, err, models.ErrTaskNotFound)
+	assert.Nil(t, task)
+}
+
+func TestGetTaskByID_ManagerOfOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	managerID := uint(2)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 3}, nil)
+	repoMock.EXPECT().FindUserByID(uint(3)).Return(&models.Users{ID: 3, ManagerID: &managerID}, nil)
+
+	task, err := service.GetTaskByID(models.Actor{UserID: managerID, Role: models.RoleManager}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), task.ID)
+}
+
+func TestUpdateTask_AdminKeepsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	task := &models.Task{ID: 1, Title: "Updated", Status: models.TaskStatusCompleted}
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 3}, nil)
+	repoMock.EXPECT().GetSubtaskProgress([]uint{1}).Return(map[uint]models.TaskProgress{}, nil)
+	// The owner hears about the status change made by the admin
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationStatus, []uint{3}).Return(nil, nil)
+	repoMock.EXPECT().CreateNotifications(gomock.Len(1)).Return(nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(got *models.Task) error {
+		assert.Equal(t, uint(3), got.UserID)
+		return nil
+	})
+
+	err := service.UpdateTask(models.Actor{UserID: 9, Role: models.RoleAdmin}, task, false)
+	assert.NoError(t, err)
+}
+
+// Subtask test cases
+func TestUpdateTask_OpenSubtasksBlockCompletion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Status: models.TaskStatusInProgress}, nil)
+	repoMock.EXPECT().GetSubtaskProgress([]uint{1}).Return(map[uint]models.TaskProgress{1: {Total: 3, Completed: 2, Percent: 66}}, nil)
+
+	err := service.UpdateTask(actor, &models.Task{ID: 1, Title: "Release", Status: models.TaskStatusCompleted}, false)
+	assert.ErrorIs(t, err, services.ErrOpenSubtasks)
+
+	// The rule is optional
+	service = services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithSubtaskPolicy(services.SubtaskPolicy{MaxDepth: 5}))
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Status: models.TaskStatusInProgress}, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+
+	err = service.UpdateTask(actor, &models.Task{ID: 1, Title: "Release", Status: models.TaskStatusCompleted}, false)
+	assert.NoError(t, err)
+}
+
+func TestUpdateTask_ParentCycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	// Task 3 sits below task 1, which cannot move under it
+	parentID := uint(3)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Status: models.TaskStatusPending}, nil)
+	repoMock.EXPECT().GetSubtaskHeight(uint(1)).Return(2, nil)
+	repoMock.EXPECT().GetTaskByID(uint(3)).Return(&models.Task{ID: 3, UserID: 2, ParentID: new(uint)}, nil)
+	repoMock.EXPECT().GetTaskAncestorIDs(uint(3)).Return([]uint{2, 1}, nil)
+
+	err := service.UpdateTask(actor, &models.Task{ID: 1, Title: "Epic", Status: models.TaskStatusPending, ParentID: &parentID}, false)
+	assert.ErrorIs(t, err, services.ErrSubtaskCycle)
+}
+
+func TestCreateTask_ParentRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithSubtaskPolicy(services.SubtaskPolicy{MaxDepth: 1}))
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	// Task 5 is a subtask already, a subtask of it would be a second level
+	parentID := uint(5)
+	repoMock.EXPECT().GetTaskByID(uint(5)).Return(&models.Task{ID: 5, UserID: 2}, nil)
+	repoMock.EXPECT().GetTaskAncestorIDs(uint(5)).Return([]uint{4}, nil)
+
+	err := service.CreateTask(&models.Task{UserID: 2, Title: "Too deep", ParentID: &parentID})
+	assert.ErrorIs(t, err, services.ErrSubtaskDepth)
+
+	// Personal subtasks stay with the parent's owner
+	repoMock.EXPECT().GetTaskByID(uint(5)).Return(&models.Task{ID: 5, UserID: 7}, nil)
+
+	err = service.CreateTask(&models.Task{UserID: 2, Title: "Foreign", ParentID: &parentID})
+	assert.ErrorIs(t, err, services.ErrParentNotFound)
+}
+
+// Dependency test cases
+func TestUpdateTask_BlockedTaskNeedsForce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Status: models.TaskStatusPending}, nil).Times(2)
+	repoMock.EXPECT().GetOpenBlockerIDs(uint(1)).Return([]uint{4}, nil)
+
+	err := service.UpdateTask(actor, &models.Task{ID: 1, Title: "Deploy", Status: models.TaskStatusInProgress}, false)
+	assert.ErrorIs(t, err, services.ErrTaskBlocked)
+
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	err = service.UpdateTask(actor, &models.Task{ID: 1, Title: "Deploy", Status: models.TaskStatusInProgress}, true)
+	assert.NoError(t, err)
+}
+
+func TestAddDependency_BlockerMustBeVisible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil).Times(2)
+	repoMock.EXPECT().GetTaskByID(uint(5)).Return(&models.Task{ID: 5, UserID: 3}, nil)
+
+	_, err := service.AddDependency(actor, 1, 5)
+	assert.ErrorIs(t, err, models.ErrTaskNotFound)
+
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{ID: 4, UserID: 2}, nil)
+	repoMock.EXPECT().AddTaskDependency(&models.TaskDependency{TaskID: 1, DependsOnID: 4, CreatedBy: 2}).Return(models.ErrDependencyCycle)
+
+	_, err = service.AddDependency(actor, 1, 4)
+	assert.ErrorIs(t, err, models.ErrDependencyCycle)
+}
+
+// Workflow test cases
+func TestUpdateTask_Transitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+	completed := &models.Task{ID: 1, UserID: 2, Status: models.TaskStatusCompleted}
+
+	// Completed tasks are reopened through In Progress, not straight back to Pending
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(completed, nil)
+	err := service.UpdateTask(actor, &models.Task{ID: 1, Title: "Ship", Status: models.TaskStatusPending}, false)
+	assert.ErrorIs(t, err, services.ErrTransitionNotAllowed)
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(completed, nil)
+	err = service.UpdateTask(actor, &models.Task{ID: 1, Title: "Ship", Status: "Archived"}, false)
+	assert.ErrorIs(t, err, services.ErrUnknownStatus)
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(completed, nil)
+	repoMock.EXPECT().GetOpenBlockerIDs(uint(1)).Return(nil, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	err = service.UpdateTask(actor, &models.Task{ID: 1, Title: "Ship", Status: models.TaskStatusInProgress}, false)
+	assert.NoError(t, err)
+}
+
+func TestUpdateWorkflow_Rules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	admin := models.Actor{UserID: 1, Role: models.RoleAdmin}
+
+	err := service.UpdateWorkflow(models.Actor{UserID: 2, Role: models.RoleManager}, models.DefaultWorkflow())
+	assert.ErrorIs(t, err, models.ErrForbidden)
+
+	// No done status
+	err = service.UpdateWorkflow(admin, &models.Workflow{
+		Statuses: []models.WorkflowStatus{{Name: "Open", Category: models.StatusCategoryTodo}},
+	})
+	assert.Error(t, err)
+
+	workflow := &models.Workflow{
+		Statuses: []models.WorkflowStatus{
+			{Name: "Backlog", Category: models.StatusCategoryTodo},
+			{Name: " Review ", Category: models.StatusCategoryDoing},
+			{Name: "Done", Category: models.StatusCategoryDone},
+		},
+		Transitions: []models.WorkflowTransition{{From: "Backlog", To: "Review"}, {From: "Review", To: "Done"}},
+	}
+	repoMock.EXPECT().ReplaceWorkflow(workflow).DoAndReturn(func(w *models.Workflow) error {
+		assert.Equal(t, models.TaskStatus("Review"), w.Statuses[1].Name)
+		assert.Equal(t, 3, w.Statuses[2].Position)
+		return nil
+	})
+	assert.NoError(t, service.UpdateWorkflow(admin, workflow))
+}
+
+func TestGetTaskTransitions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Status: models.TaskStatusCompleted}, nil)
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil)
+
+	got, err := service.GetTaskTransitions(models.Actor{UserID: 2, Role: models.RoleUser}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCategoryDone, got.Category)
+	assert.Len(t, got.Next, 1)
+	assert.Equal(t, models.TaskStatusInProgress, got.Next[0].Name)
+}
+
+// History test cases
+func TestUpdateTask_RecordsHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	existing := &models.Task{ID: 1, UserID: 2, Title: "Draft", Status: models.TaskStatusPending, Priority: models.TaskPriorityMedium}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(existing, nil)
+	repoMock.EXPECT().GetOpenBlockerIDs(uint(1)).Return(nil, nil)
+	repoMock.EXPECT().GetMutedUserIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).DoAndReturn(func(entry *models.TaskHistory) error {
+		assert.Equal(t, uint(3), entry.ActorID)
+		assert.Equal(t, models.HistoryUpdated, entry.Action)
+		assert.Equal(t, []models.FieldChange{
+			{Field: "title", Before: "Draft", After: "Final"},
+			{Field: "status", Before: "Pending", After: "In Progress"},
+		}, entry.Changes)
+		return nil
+	})
+
+	// A manager of user 2
+	managerID := uint(3)
+	repoMock.EXPECT().FindUserByID(uint(2)).Return(&models.Users{ID: 2, ManagerID: &managerID}, nil)
+
+	err := service.UpdateTask(models.Actor{UserID: 3, Role: models.RoleManager},
+		&models.Task{ID: 1, Title: "Final", Status: models.TaskStatusInProgress}, false)
+	assert.NoError(t, err)
+}
+
+func TestGetTaskHistory_DeletedTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	deleted := &models.TaskHistory{ID: 9, TaskID: 1, ActorID: 2, Action: models.HistoryDeleted, OwnerID: 2}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(nil, models.ErrTaskNotFound).Times(2)
+	repoMock.EXPECT().GetLastTaskHistory(uint(1)).Return(deleted, nil).Times(2)
+	repoMock.EXPECT().GetTaskHistory(uint(1), 1, 50).Return([]models.TaskHistory{*deleted}, int64(1), nil)
+
+	history, total, err := service.GetTaskHistory(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, models.HistoryDeleted, history[0].Action)
+
+	_, _, err = service.GetTaskHistory(models.Actor{UserID: 5, Role: models.RoleUser}, 1, 1, 50)
+	assert.ErrorIs(t, err, models.ErrTaskNotFound)
+}
+
+func TestGetAllTasks_UserScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetFilteredTasks(models.TaskFilter{OwnerIDs: []uint{2}, MemberID: 2, Page: 1, Limit: 10}).Return(nil, int64(0), nil)
+
+	_, _, err := service.GetAllTasks(models.Actor{UserID: 2, Role: models.RoleUser}, models.TaskFilter{Page: 1, Limit: 10})
+	assert.NoError(t, err)
+}
+
+// Priority test cases
+func TestCreateTask_Priority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	repoMock.EXPECT().CreateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, models.TaskPriorityMedium, task.Priority)
+		return nil
+	})
+	err := service.CreateTask(&models.Task{Title: "a", UserID: 2})
+	assert.NoError(t, err)
+
+	err = service.CreateTask(&models.Task{Title: "b", UserID: 2, Priority: "critical"})
+	assert.Error(t, err)
+}
+
+func TestUpdateTask_KeepsPriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, Priority: models.TaskPriorityUrgent}, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, models.TaskPriorityUrgent, task.Priority)
+		return nil
+	})
+
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, &models.Task{ID: 1, Title: "renamed"}, false)
+	assert.NoError(t, err)
+}
+
+// Project test cases
+func TestGetTaskByID_ProjectMembership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	projectID := uint(4)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 3, ProjectID: &projectID}, nil).Times(2)
+
+	// Any member may read, whoever owns the task
+	repoMock.EXPECT().IsProjectMember(projectID, uint(2)).Return(true, nil)
+	task, err := service.GetTaskByID(models.Actor{UserID: 2, Role: models.RoleUser}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), task.ID)
+
+	// The owner lost access by leaving the project
+	repoMock.EXPECT().IsProjectMember(projectID, uint(3)).Return(false, nil)
+	_, err = service.GetTaskByID(models.Actor{UserID: 3, Role: models.RoleUser}, 1)
+	assert.ErrorIs(t, err, models.ErrTaskNotFound)
+}
+
+func TestCreateTask_ProjectChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	archived, open := uint(4), uint(5)
+	repoMock.EXPECT().GetProjectByID(archived).Return(&models.Project{ID: archived, Archived: true, MemberIDs: []uint{2}}, nil)
+	repoMock.EXPECT().GetProjectByID(open).Return(&models.Project{ID: open, MemberIDs: []uint{3}}, nil)
+
+	err := service.CreateTask(&models.Task{Title: "a", UserID: 2, ProjectID: &archived})
+	assert.ErrorIs(t, err, services.ErrProjectArchived)
+
+	err = service.CreateTask(&models.Task{Title: "b", UserID: 2, ProjectID: &open})
+	assert.ErrorIs(t, err, models.ErrProjectNotFound)
+}
+
+func TestUpdateTask_KeepsProject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	projectID := uint(4)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{
+		ID: 1, UserID: 3, Title: "a", Status: models.TaskStatusPending, ProjectID: &projectID,
+	}, nil).Times(2)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(2)).Return(true, nil).Times(2)
+
+	// Leaving projectId out keeps the task on its project
+	repoMock.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, uint(3), task.UserID)
+		assert.Equal(t, &projectID, task.ProjectID)
+		return nil
+	})
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, &models.Task{ID: 1, Title: "b"}, false)
+	assert.NoError(t, err)
+
+	// 0 takes it off the project
+	none := uint(0)
+	repoMock.EXPECT().GetSubtaskHeight(uint(1)).Return(0, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Nil(t, task.ProjectID)
+		return nil
+	})
+	err = service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, &models.Task{ID: 1, Title: "b", ProjectID: &none}, false)
+	assert.NoError(t, err)
+}
+
+func TestRemoveProjectMember_Rules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	project := &models.Project{ID: 4, OwnerID: 1, MemberIDs: []uint{1, 2, 3}}
+	repoMock.EXPECT().GetProjectByID(uint(4)).Return(project, nil).AnyTimes()
+
+	// Members cannot remove each other, but may leave
+	err := service.RemoveProjectMember(models.Actor{UserID: 2, Role: models.RoleUser}, 4, 3)
+	assert.ErrorIs(t, err, models.ErrForbidden)
+
+	repoMock.EXPECT().RemoveProjectMember(uint(4), uint(2)).Return(nil)
+	err = service.RemoveProjectMember(models.Actor{UserID: 2, Role: models.RoleUser}, 4, 2)
+	assert.NoError(t, err)
+
+	err = service.RemoveProjectMember(models.Actor{UserID: 1, Role: models.RoleUser}, 4, 1)
+	assert.Error(t, err)
+}
+
+func TestDeleteProject_DetachesOtherOwnersSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	projectID := uint(4)
+	parent, mine, theirs := uint(10), uint(11), uint(12)
+	repoMock.EXPECT().GetProjectByID(projectID).Return(&models.Project{ID: projectID, OwnerID: 1, MemberIDs: []uint{1, 2}}, nil)
+	repoMock.EXPECT().GetFilteredTasks(models.TaskFilter{ProjectID: projectID}).Return([]models.Task{
+		{ID: parent, UserID: 1, ProjectID: &projectID},
+		{ID: mine, UserID: 1, ProjectID: &projectID, ParentID: &parent},
+		{ID: theirs, UserID: 2, ProjectID: &projectID, ParentID: &parent},
+		{ID: 13, UserID: 2, ProjectID: &projectID, ParentID: &theirs},
+	}, int64(4), nil)
+
+	// Only the subtask of another owner's task leaves its parent
+	repoMock.EXPECT().DeleteProject(projectID, []uint{theirs}).Return(nil)
+	err := service.DeleteProject(models.Actor{UserID: 1, Role: models.RoleUser}, projectID)
+	assert.NoError(t, err)
+}
+
+func TestGetProjectTasks_NonMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetProjectByID(uint(4)).Return(&models.Project{ID: 4, OwnerID: 1, MemberIDs: []uint{1}}, nil).Times(2)
+
+	_, _, err := service.GetProjectTasks(models.Actor{UserID: 2, Role: models.RoleManager}, 4, models.TaskFilter{})
+	assert.ErrorIs(t, err, models.ErrProjectNotFound)
+
+	repoMock.EXPECT().GetFilteredTasks(models.TaskFilter{ProjectID: 4, Page: 1, Limit: 10}).Return(nil, int64(0), nil)
+	_, _, err = service.GetProjectTasks(models.Actor{UserID: 1, Role: models.RoleUser}, 4, models.TaskFilter{OwnerIDs: []uint{1}, Page: 1, Limit: 10})
+	assert.NoError(t, err)
+}
+
+// Assignee test cases
+func TestUpdateAssignees_AssigneeMustSeeTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	// A personal task of user 2, invisible to user 3
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil)
+	repoMock.EXPECT().FindUserByID(uint(3)).Return(&models.Users{ID: 3, Role: models.RoleUser}, nil)
+
+	_, err := service.UpdateAssignees(models.Actor{UserID: 2, Role: models.RoleUser}, 1, []uint{3}, nil)
+	assert.ErrorIs(t, err, services.ErrAssigneeCannotSeeTask)
+}
+
+func TestUpdateAssignees_ProjectMember(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	projectID := uint(4)
+	task := &models.Task{ID: 1, UserID: 2, ProjectID: &projectID}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(task, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(2)).Return(true, nil)
+	repoMock.EXPECT().FindUserByID(uint(3)).Return(&models.Users{ID: 3, Role: models.RoleUser}, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(3)).Return(true, nil)
+	repoMock.EXPECT().UpdateTaskAssignees(uint(1), []uint{3}, []uint{5}, uint(2)).Return(nil)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, ProjectID: &projectID, AssigneeIDs: []uint{3}}, nil)
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationAssignment, []uint{3}).Return(nil, nil)
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).DoAndReturn(func(got []models.Notification) error {
+		assert.Len(t, got, 1)
+		assert.Equal(t, uint(3), got[0].UserID)
+		assert.Equal(t, models.NotificationAssignment, got[0].Type)
+		return nil
+	})
+
+	got, err := service.UpdateAssignees(models.Actor{UserID: 2, Role: models.RoleUser}, 1, []uint{3}, []uint{5})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3}, got.AssigneeIDs)
+}
+
+func TestUpdateAssignees_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil)
+	repoMock.EXPECT().FindUserByID(uint(9)).Return(nil, models.ErrUserNotFound)
+
+	_, err := service.UpdateAssignees(models.Actor{UserID: 2, Role: models.RoleUser}, 1, []uint{9}, nil)
+	assert.ErrorIs(t, err, models.ErrUserNotFound)
+}
+
+// Label test cases
+func TestAttachLabels_OtherUsersLabel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil)
+	repoMock.EXPECT().GetLabelByID(uint(7)).Return(&models.Label{ID: 7, UserID: 3, Name: "bug"}, nil)
+
+	_, err := service.AttachLabels(models.Actor{UserID: 2, Role: models.RoleUser}, 1, []uint{7})
+	assert.ErrorIs(t, err, models.ErrLabelNotFound)
+}
+
+func TestCreateLabel_Validation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	err := service.CreateLabel(actor, &models.Label{Name: "bug", Color: "red"})
+	assert.Error(t, err)
+
+	repoMock.EXPECT().FindLabelByName(uint(2), "bug").Return(&models.Label{ID: 4, UserID: 2, Name: "Bug"}, nil)
+	err = service.CreateLabel(actor, &models.Label{Name: " bug "})
+	assert.ErrorIs(t, err, services.ErrLabelExists)
+
+	repoMock.EXPECT().FindLabelByName(uint(2), "frontend").Return(nil, models.ErrLabelNotFound)
+	repoMock.EXPECT().CreateLabel(&models.Label{UserID: 2, Name: "frontend", Color: "#808080"}).Return(nil)
+	assert.NoError(t, service.CreateLabel(actor, &models.Label{Name: "frontend"}))
+}
+
+func TestUpdateUserRole_RequiresAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	err := service.UpdateUserRole(models.Actor{UserID: 2, Role: models.RoleManager}, 3, models.RoleAdmin, nil)
+	assert.ErrorIs(t, err, models.ErrForbidden)
+}
+
+// Admin user management test cases
+func TestGetUserDetail_TaskCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().FindUserByID(uint(3)).Return(&models.Users{ID: 3, Username: "alice@example.com"}, nil)
+	repoMock.EXPECT().CountTasksByStatus(uint(3)).Return(map[models.TaskStatus]int64{
+		models.TaskStatusPending:   2,
+		models.TaskStatusCompleted: 5,
+	}, nil)
+
+	detail, err := service.GetUserDetail(models.Actor{UserID: 1, Role: models.RoleAdmin}, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), detail.TotalTasks)
+}
+
+func TestDeleteUser_RejectsSelfReassign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	target := uint(3)
+	err := service.DeleteUser(models.Actor{UserID: 1, Role: models.RoleAdmin}, 3, &target)
+	assert.Error(t, err)
+}
+
+// captureMailer records the last mail instead of sending it
+type captureMailer struct {
+	to, subject, body string
+	sent              int
+}
+
+func (m *captureMailer) Send(to, subject, body string) error {
+	m.to, m.subject, m.body = to, subject, body
+	m.sent++
+	return nil
+}
+
+// Password reset test cases
+func TestForgotPassword_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	mail := &captureMailer{}
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithMailer(mail, "http://localhost:3000"))
+
+	repoMock.EXPECT().GetUserByUsername("nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	err := service.ForgotPassword("nobody@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mail.sent)
+}
+
+func TestResetPassword_TokenDiesWithOldPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	mail := &captureMailer{}
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithMailer(mail, "http://localhost:3000"))
+
+	user := &models.Users{ID: 1, Username: "testuser@example.com", PasswordHash: "old-hash"}
+	repoMock.EXPECT().GetUserByUsername(user.Username).Return(user, nil)
+
+	err := service.ForgotPassword(user.Username)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Username, mail.to)
+
+	lines := strings.Split(strings.TrimSpace(mail.body), "\n")
+	token := lines[len(lines)-1]
+
+	// The password changed since the token was issued
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(&models.Users{ID: 1, Username: user.Username, PasswordHash: "new-hash"}, nil)
+
+	err = service.ResetPassword(token, "a-new-password")
+	assert.ErrorIs(t, err, services.ErrInvalidActionToken)
+}
+
+func TestResetPassword_TooShort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	err := service.ResetPassword("token", "short")
+	assert.Error(t, err)
+}
+
+// API key test cases
+func TestAuthenticateAPIKey_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	var stored models.APIKey
+	repoMock.EXPECT().CreateAPIKey(gomock.Any()).DoAndReturn(func(key *models.APIKey) error {
+		key.ID = 7
+		stored = *key
+		return nil
+	})
+
+	created, err := service.CreateAPIKey(1, "ci", []string{models.ScopeTasksRead}, 0)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, "tm_"))
+	assert.NotContains(t, stored.KeyHash, created.Key)
+
+	done := make(chan struct{})
+	repoMock.EXPECT().GetAPIKeyByPrefix(stored.Prefix).Return(&stored, nil).Times(2)
+	repoMock.EXPECT().FindUserByID(uint(1)).Return(&models.Users{ID: 1, Username: "ci@example.com"}, nil)
+	repoMock.EXPECT().TouchAPIKey(uint(7), gomock.Any()).DoAndReturn(func(uint, time.Time) error {
+		close(done)
+		return nil
+	})
+
+	user, key, err := service.AuthenticateAPIKey(created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), user.ID)
+	assert.Equal(t, []string{models.ScopeTasksRead}, key.ScopeList())
+	<-done
+
+	// A key with the right prefix but the wrong secret is refused
+	_, _, err = service.AuthenticateAPIKey("tm_" + stored.Prefix + "_forged")
+	assert.ErrorIs(t, err, services.ErrInvalidAPIKey)
+}
+
+func TestCreateAPIKey_InvalidScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	_, err := service.CreateAPIKey(1, "ci", []string{"admin:everything"}, 0)
+	assert.Error(t, err)
+}
+
+// Trash test cases
+func TestRestoreTask_FallsBackToInitialStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	parentID := uint(5)
+	trashed := &models.Task{ID: 1, UserID: 2, Title: "Old", Status: "Review", ParentID: &parentID}
+	restored := &models.Task{ID: 1, UserID: 2, Title: "Old", Status: models.TaskStatusPending}
+	repoMock.EXPECT().GetTrashedTask(uint(1)).Return(trashed, nil)
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil)
+	// The parent was purged while the task was in the trash
+	repoMock.EXPECT().GetTaskByID(uint(5)).Return(nil, models.ErrTaskNotFound)
+	repoMock.EXPECT().RestoreTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, models.TaskStatusPending, task.Status)
+		assert.Nil(t, task.ParentID)
+		return nil
+	})
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(restored, nil)
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).DoAndReturn(func(entry *models.TaskHistory) error {
+		assert.Equal(t, models.HistoryRestored, entry.Action)
+		return nil
+	})
+
+	task, err := service.RestoreTask(models.Actor{UserID: 2, Role: models.RoleUser}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, restored, task)
+}
+
+func TestRestoreTask_OtherUsersTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTrashedTask(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil)
+
+	_, err := service.RestoreTask(models.Actor{UserID: 3, Role: models.RoleUser}, 1)
+	assert.ErrorIs(t, err, models.ErrTaskNotFound)
+}
+
+func TestGetTrash_PurgeAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithTrashRetention(48*time.Hour))
+
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repoMock.EXPECT().GetTrashedTasks(uint(2), 1, 50).Return([]models.Task{
+		{ID: 1, UserID: 2, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}, int64(1), nil)
+
+	trash, total, err := service.GetTrash(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, deletedAt, trash[0].DeletedAt)
+	assert.Equal(t, deletedAt.Add(48*time.Hour), *trash[0].PurgeAt)
+}
+
+func TestPurgeExpiredTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithTrashRetention(24*time.Hour))
+
+	repoMock.EXPECT().PurgeTrashedTasks(uint(0), gomock.Any()).DoAndReturn(func(userID uint, before time.Time) (int64, error) {
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+		return 3, nil
+	})
+	purged, err := service.PurgeExpiredTrash()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	// Without retention the trash is only emptied by hand
+	keep := services.NewTaskService(repoMock, nil, log.Default(), services.WithTrashRetention(0))
+	purged, err = keep.PurgeExpiredTrash()
+	assert.NoError(t, err)
+	assert.Zero(t, purged)
+}
+
+// Comment test cases
+func TestUpdateComment_AuthorOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	task := &models.Task{ID: 1, UserID: 2}
+	comment := &models.Comment{ID: 9, TaskID: 1, AuthorID: 2, Body: "first"}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(task, nil).AnyTimes()
+	repoMock.EXPECT().GetCommentByID(uint(9)).DoAndReturn(func(uint) (*models.Comment, error) {
+		c := *comment
+		return &c, nil
+	}).AnyTimes()
+
+	// An admin can read the task but not edit someone else's comment
+	_, err := service.UpdateComment(models.Actor{UserID: 1, Role: models.RoleAdmin}, 1, 9, "changed")
+	assert.ErrorIs(t, err, models.ErrForbidden)
+
+	_, err = service.UpdateComment(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 9, "   ")
+	assert.ErrorIs(t, err, services.ErrInvalidComment)
+
+	repoMock.EXPECT().UpdateComment(gomock.Any(), gomock.Any()).DoAndReturn(func(c *models.Comment, edit *models.CommentEdit) error {
+		assert.Equal(t, "**second**", c.Body)
+		assert.Equal(t, "first", edit.Body)
+		assert.Equal(t, uint(2), edit.EditorID)
+		return nil
+	})
+	updated, err := service.UpdateComment(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 9, " **second** ")
+	assert.NoError(t, err)
+	assert.NotNil(t, updated.EditedAt)
+}
+
+func TestDeleteComment_AdminModerates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil).AnyTimes()
+	repoMock.EXPECT().GetCommentByID(uint(9)).Return(&models.Comment{ID: 9, TaskID: 1, AuthorID: 3}, nil).AnyTimes()
+	// Comments are looked up through their task
+	repoMock.EXPECT().GetCommentByID(uint(10)).Return(&models.Comment{ID: 10, TaskID: 4, AuthorID: 2}, nil)
+
+	err := service.DeleteComment(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 9)
+	assert.ErrorIs(t, err, models.ErrForbidden)
+
+	err = service.DeleteComment(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 10)
+	assert.ErrorIs(t, err, models.ErrCommentNotFound)
+
+	repoMock.EXPECT().DeleteComment(uint(9)).Return(nil)
+	err = service.DeleteComment(models.Actor{UserID: 1, Role: models.RoleAdmin}, 1, 9)
+	assert.NoError(t, err)
+}
+
+// Attachment test cases
+func TestUploadAttachment_Limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithAttachments(store, services.AttachmentPolicy{
+		MaxSize:      1024,
+		AllowedTypes: []string{"text/plain", "image/png"},
+		UserQuota:    2048,
+	}))
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2}, nil).AnyTimes()
+
+	_, err = service.UploadAttachment(actor, 1, "big.txt", 4096, strings.NewReader(strings.Repeat("a", 4096)))
+	assert.ErrorIs(t, err, services.ErrAttachmentSize)
+
+	// The content decides the type, not the file name
+	_, err = service.UploadAttachment(actor, 1, "notes.txt", 6, strings.NewReader("%PDF-1"))
+	assert.ErrorIs(t, err, services.ErrAttachmentType)
+
+	repoMock.EXPECT().GetAttachmentUsage(uint(2)).Return(int64(2040), nil)
+	_, err = service.UploadAttachment(actor, 1, "notes.txt", 11, strings.NewReader("hello world"))
+	assert.ErrorIs(t, err, models.ErrStorageQuotaExceeded)
+
+	repoMock.EXPECT().GetAttachmentUsage(uint(2)).Return(int64(0), nil)
+	repoMock.EXPECT().CreateAttachment(gomock.Any(), int64(2048)).Return(nil)
+	attachment, err := service.UploadAttachment(actor, 1, "../../notes.txt", 11, strings.NewReader("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, "notes.txt", attachment.FileName)
+	assert.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+
+	stored, err := store.Get(context.Background(), attachment.StorageKey)
+	assert.NoError(t, err)
+	stored.Close()
+}
+
+func TestDeleteAttachment_UploaderOrEditor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store, err := blobstore.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithAttachments(store, services.DefaultAttachmentPolicy))
+
+	// Members of the project may modify its tasks, so they may delete anyone's upload
+	projectID := uint(4)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, ProjectID: &projectID}, nil).AnyTimes()
+	repoMock.EXPECT().IsProjectMember(projectID, gomock.Any()).Return(true, nil).AnyTimes()
+	repoMock.EXPECT().GetAttachmentByID(uint(7)).Return(&models.Attachment{ID: 7, TaskID: 1, UploaderID: 3, StorageKey: "tasks/1/x"}, nil).AnyTimes()
+
+	repoMock.EXPECT().DeleteAttachment(uint(7)).Return(nil)
+	err = service.DeleteAttachment(models.Actor{UserID: 5, Role: models.RoleUser}, 1, 7)
+	assert.NoError(t, err)
+
+	// Attachments are only found through their own task
+	repoMock.EXPECT().GetTaskByID(uint(2)).Return(&models.Task{ID: 2, UserID: 5}, nil)
+	_, _, err = service.OpenAttachment(models.Actor{UserID: 5, Role: models.RoleUser}, 2, 7)
+	assert.ErrorIs(t, err, models.ErrAttachmentNotFound)
+}
+
+// Notification test cases
+func TestAddComment_NotifiesMentionedReaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	projectID := uint(4)
+	task := &models.Task{ID: 1, UserID: 2, Title: "Ship it", ProjectID: &projectID}
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(task, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(2)).Return(true, nil)
+	repoMock.EXPECT().CreateComment(gomock.Any()).DoAndReturn(func(comment *models.Comment) error {
+		comment.ID = 9
+		return nil
+	})
+	// Outsiders cannot read the task and are not told about it
+	repoMock.EXPECT().GetUsersByUsernames([]string{"bob@example.com", "eve@example.com"}).Return([]models.Users{
+		{ID: 3, Username: "bob@example.com", Role: models.RoleUser},
+		{ID: 6, Username: "eve@example.com", Role: models.RoleUser},
+	}, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(3)).Return(true, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(6)).Return(false, nil)
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationMention, []uint{3}).Return(nil, nil)
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).DoAndReturn(func(got []models.Notification) error {
+		assert.Len(t, got, 1)
+		assert.Equal(t, uint(3), got[0].UserID)
+		assert.Equal(t, uint(2), got[0].ActorID)
+		assert.Equal(t, uint(9), *got[0].CommentID)
+		return nil
+	})
+
+	_, err := service.AddComment(models.Actor{UserID: 2, Role: models.RoleUser}, 1, "@Bob@example.com and @eve@example.com, see @bob@example.com.")
+	assert.NoError(t, err)
+}
+
+func TestUpdateAssignees_MutedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	projectID := uint(4)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, ProjectID: &projectID, AssigneeIDs: []uint{5}}, nil)
+	repoMock.EXPECT().IsProjectMember(projectID, uint(2)).Return(true, nil)
+	repoMock.EXPECT().UpdateTaskAssignees(uint(1), nil, []uint{5}, uint(2)).Return(nil)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{ID: 1, UserID: 2, ProjectID: &projectID}, nil)
+	// Nothing is stored for users who turned assignment notifications off
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationAssignment, []uint{5}).Return([]uint{5}, nil)
+
+	_, err := service.UpdateAssignees(models.Actor{UserID: 2, Role: models.RoleUser}, 1, nil, []uint{5})
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	_, err := service.UpdateNotificationPreferences(actor, map[string]bool{"digest": false})
+	assert.Error(t, err)
+
+	repoMock.EXPECT().SetNotificationPreferences(uint(2), map[string]bool{models.NotificationStatus: false}).Return(nil)
+	repoMock.EXPECT().GetNotificationPreferences(uint(2)).Return([]models.NotificationPreference{
+		{UserID: 2, Type: models.NotificationStatus, Enabled: false},
+	}, nil)
+	prefs, err := service.UpdateNotificationPreferences(actor, map[string]bool{models.NotificationStatus: false})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{
+		models.NotificationMention:    true,
+		models.NotificationAssignment: true,
+		models.NotificationStatus:     false,
+		models.NotificationReminder:   true,
+	}, prefs)
+}
+
+func TestListNotifications_UnreadCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	repoMock.EXPECT().GetNotifications(uint(2), false, 1, 50).Return([]models.Notification{{ID: 1}}, int64(4), nil)
+	repoMock.EXPECT().CountUnreadNotifications(uint(2)).Return(int64(3), nil)
+	_, total, unread, err := service.ListNotifications(actor, false, 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, int64(3), unread)
+
+	// Listing only unread notifications counts them on the way
+	repoMock.EXPECT().GetNotifications(uint(2), true, 1, 50).Return([]models.Notification{{ID: 1}}, int64(3), nil)
+	_, _, unread, err = service.ListNotifications(actor, true, 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), unread)
+}
+
+// Reminder test cases
+func TestSendDueReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var got []notifier.Reminder
+	email := notifier.Func(func(_ context.Context, r notifier.Reminder) error {
+		got = append(got, r)
+		return nil
+	})
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithReminders(services.DefaultReminderPolicy, email))
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil)
+
+	now := time.Now()
+	soon := now.Add(2 * time.Hour)
+	later := now.Add(3 * 24 * time.Hour)
+	overdue := now.Add(-time.Hour)
+	// A custom two hour reminder is not due yet at three hours before the due date
+	custom := now.Add(3 * time.Hour)
+	repoMock.EXPECT().GetDueTasks(gomock.Any(), gomock.Any(), []models.TaskStatus{models.TaskStatusCompleted}).Return([]models.Task{
+		{ID: 1, UserID: 2, Title: "Soon", DueDate: &soon, AssigneeIDs: []uint{3, 2}},
+		{ID: 2, UserID: 2, Title: "Later", DueDate: &later},
+		{ID: 3, UserID: 2, Title: "Overdue", DueDate: &overdue},
+		{ID: 4, UserID: 2, Title: "Custom", DueDate: &custom, ReminderMinutes: []int{120}},
+	}, nil)
+
+	repoMock.EXPECT().ClaimReminder(gomock.Any()).DoAndReturn(func(claim *models.TaskReminder) (bool, error) {
+		switch claim.TaskID {
+		case 1:
+			assert.Equal(t, 1440, claim.OffsetMinutes)
+			return true, nil
+		case 3:
+			assert.Equal(t, 0, claim.OffsetMinutes)
+			// Another replica already sent it
+			return false, nil
+		}
+		t.Fatalf("unexpected reminder of task %d", claim.TaskID)
+		return false, nil
+	}).Times(2)
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationReminder, []uint{2, 3}).Return([]uint{3}, nil)
+	repoMock.EXPECT().GetUsersByIDs([]uint{2}).Return([]models.Users{{ID: 2, Username: "jane@example.com"}}, nil)
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).DoAndReturn(func(notifications []models.Notification) error {
+		assert.Equal(t, models.NotificationReminder, notifications[0].Type)
+		assert.Equal(t, uint(2), notifications[0].UserID)
+		return nil
+	})
+
+	sent, err := service.SendDueReminders(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "jane@example.com", got[0].Username)
+		assert.Equal(t, 24*time.Hour, got[0].Before)
+	}
+}
+
+func TestSendDueReminders_ReleasesFailedReminder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failing := notifier.Func(func(context.Context, notifier.Reminder) error { return errors.New("smtp down") })
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	policy := services.DefaultReminderPolicy
+	policy.InApp = false
+	service := services.NewTaskService(repoMock, nil, log.Default(), services.WithReminders(policy, failing))
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil)
+
+	overdue := time.Now().Add(-time.Minute)
+	repoMock.EXPECT().GetDueTasks(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Task{
+		{ID: 1, UserID: 2, Title: "Overdue", DueDate: &overdue},
+	}, nil)
+	repoMock.EXPECT().ClaimReminder(gomock.Any()).Return(true, nil)
+	repoMock.EXPECT().GetMutedUserIDs(models.NotificationReminder, []uint{2}).Return(nil, nil)
+	repoMock.EXPECT().GetUsersByIDs([]uint{2}).Return([]models.Users{{ID: 2, Username: "jane@example.com"}}, nil)
+	repoMock.EXPECT().ReleaseReminder(gomock.Any()).DoAndReturn(func(claim *models.TaskReminder) error {
+		assert.Equal(t, uint(1), claim.TaskID)
+		assert.Equal(t, 0, claim.OffsetMinutes)
+		return nil
+	})
+
+	sent, err := service.SendDueReminders(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+}
+
+func TestCreateTask_InvalidReminders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+
+	err := service.CreateTask(&models.Task{UserID: 2, Title: "Chore", ReminderMinutes: []int{0}})
+	assert.ErrorIs(t, err, services.ErrInvalidReminder)
+
+	err = service.CreateTask(&models.Task{UserID: 2, Title: "Chore", ReminderMinutes: []int{60 * 24 * 31}})
+	assert.ErrorIs(t, err, services.ErrInvalidReminder)
+}
+
+// Recurrence test cases
+func TestUpdateTask_CompletingCreatesNextOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetMutedUserIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).Return(nil).AnyTimes()
+
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	seriesID := uint(1)
+	existing := &models.Task{
+		ID: 4, UserID: 2, Title: "Backups", Status: models.TaskStatusInProgress, Priority: models.TaskPriorityHigh,
+		DueDate: &due, Recurrence: "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=5", SeriesID: &seriesID, Occurrence: 3,
+		AssigneeIDs: []uint{3}, Labels: []models.Label{{ID: 8, UserID: 2, Name: "ops"}},
+	}
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(existing, nil)
+	repoMock.EXPECT().GetSubtaskProgress([]uint{4}).Return(map[uint]models.TaskProgress{}, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	repoMock.EXPECT().CreateOccurrence(gomock.Any()).DoAndReturn(func(next *models.Task) (bool, error) {
+		assert.Equal(t, "Backups", next.Title)
+		assert.Equal(t, models.TaskStatusPending, next.Status)
+		assert.Equal(t, models.TaskPriorityHigh, next.Priority)
+		assert.Equal(t, time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC), *next.DueDate)
+		assert.Equal(t, uint(1), *next.SeriesID)
+		assert.Equal(t, 4, next.Occurrence)
+		next.ID = 5
+		return true, nil
+	})
+	repoMock.EXPECT().UpdateTaskAssignees(uint(5), []uint{3}, nil, uint(2)).Return(nil)
+	repoMock.EXPECT().AttachLabels(uint(5), []uint{8}).Return(nil)
+
+	task := &models.Task{ID: 4, Title: "Backups", Status: models.TaskStatusCompleted, DueDate: &due}
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, task, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=5", task.Recurrence)
+}
+
+func TestUpdateTask_LastOccurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	seriesID := uint(1)
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{
+		ID: 4, UserID: 2, Status: models.TaskStatusPending, DueDate: &due,
+		Recurrence: "FREQ=DAILY;COUNT=2", SeriesID: &seriesID, Occurrence: 2,
+	}, nil)
+	repoMock.EXPECT().GetSubtaskProgress([]uint{4}).Return(map[uint]models.TaskProgress{}, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+
+	// No CreateOccurrence call, the series is complete
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser},
+		&models.Task{ID: 4, Title: "Water plants", Status: models.TaskStatusCompleted, DueDate: &due}, false)
+	assert.NoError(t, err)
+}
+
+func TestUpdateTask_RecurringKeepsDueDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	seriesID := uint(1)
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{
+		ID: 4, UserID: 2, Title: "Backups", Status: models.TaskStatusPending, DueDate: &due,
+		Recurrence: "FREQ=DAILY", SeriesID: &seriesID, Occurrence: 1,
+	}, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *models.Task) error {
+		assert.Equal(t, &due, task.DueDate)
+		assert.Equal(t, "FREQ=DAILY", task.Recurrence)
+		return nil
+	})
+
+	// Leaving dueDate out keeps it, so the rule still has its anchor
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, &models.Task{ID: 4, Title: "Nightly backups"}, false)
+	assert.NoError(t, err)
+}
+
+func TestCreateTask_Recurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	err := service.CreateTask(&models.Task{UserID: 2, Title: "Chore", Recurrence: "FREQ=WEEKLY"})
+	assert.ErrorIs(t, err, services.ErrRecurrenceNeedsDueDate)
+
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	err = service.CreateTask(&models.Task{UserID: 2, Title: "Chore", DueDate: &due, Recurrence: "FREQ=HOURLY"})
+	assert.Error(t, err)
+
+	repoMock.EXPECT().CreateTask(gomock.Any()).Return(nil)
+	task := &models.Task{UserID: 2, Title: "Chore", DueDate: &due, Recurrence: "rrule:freq=weekly;interval=1", Occurrence: 7}
+	err = service.CreateTask(task)
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY", task.Recurrence)
+	assert.Equal(t, 1, task.Occurrence)
+	assert.Nil(t, task.SeriesID)
+}
+
+func TestStopRecurrence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+
+	due := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	seriesID := uint(1)
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{
+		ID: 4, UserID: 2, DueDate: &due, Recurrence: "FREQ=DAILY", SeriesID: &seriesID, Occurrence: 4,
+	}, nil)
+	repoMock.EXPECT().StopTaskSeries(uint(1)).Return([]uint{1, 4}, nil)
+
+	task, err := service.StopRecurrence(models.Actor{UserID: 2, Role: models.RoleUser}, 4)
+	assert.NoError(t, err)
+	assert.Empty(t, task.Recurrence)
+
+	repoMock.EXPECT().GetTaskByID(uint(5)).Return(&models.Task{ID: 5, UserID: 2}, nil)
+	_, err = service.StopRecurrence(models.Actor{UserID: 2, Role: models.RoleUser}, 5)
+	assert.ErrorIs(t, err, services.ErrNotRecurring)
+}
+
+func TestPreviewRecurrence_StopsAtCount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	due := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+	repoMock.EXPECT().GetTaskByID(uint(1)).Return(&models.Task{
+		ID: 1, UserID: 2, DueDate: &due, Recurrence: "FREQ=MONTHLY;COUNT=3", Occurrence: 1,
+	}, nil)
+
+	occurrences, err := service.PreviewRecurrence(models.Actor{UserID: 2, Role: models.RoleUser}, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC),
+	}, occurrences)
+}
+
+// Webhook test cases
+func TestUpdateTask_PublishesWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithWebhooks(webhook.NewSender(time.Second), services.DefaultWebhookPolicy))
+	repoMock.EXPECT().GetWorkflow().Return(models.DefaultWorkflow(), nil).AnyTimes()
+	repoMock.EXPECT().CreateTaskHistory(gomock.Any()).Return(nil).AnyTimes()
+	repoMock.EXPECT().GetMutedUserIDs(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	repoMock.EXPECT().CreateNotifications(gomock.Any()).Return(nil).AnyTimes()
+
+	repoMock.EXPECT().GetTaskByID(uint(4)).Return(&models.Task{ID: 4, UserID: 2, Title: "Deploy", Status: models.TaskStatusPending}, nil)
+	repoMock.EXPECT().GetOpenBlockerIDs(uint(4)).Return(nil, nil)
+	repoMock.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+
+	// User 9 cannot see the task and gets nothing
+	subs := []models.WebhookSubscription{{ID: 1, UserID: 2, Active: true}, {ID: 2, UserID: 9, Active: true}}
+	owners := []models.Users{{ID: 2, Role: models.RoleUser}, {ID: 9, Role: models.RoleUser}}
+	for _, event := range []string{models.WebhookTaskUpdated, models.WebhookTaskStatusChanged} {
+		event := event
+		repoMock.EXPECT().GetActiveWebhooks(event).Return(subs, nil)
+		repoMock.EXPECT().GetUsersByIDs([]uint{2, 9}).Return(owners, nil)
+		repoMock.EXPECT().CreateWebhookDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+			assert.Len(t, deliveries, 1)
+			assert.Equal(t, uint(1), deliveries[0].SubscriptionID)
+			assert.Equal(t, models.WebhookDeliveryPending, deliveries[0].Status)
+			assert.NotNil(t, deliveries[0].NextAttemptAt)
+
+			var payload services.WebhookPayload
+			assert.NoError(t, json.Unmarshal(deliveries[0].Payload, &payload))
+			assert.Equal(t, event, payload.Event)
+			assert.Equal(t, uint(2), payload.ActorID)
+			assert.Equal(t, uint(4), payload.Task.ID)
+			assert.Contains(t, payload.Changes, models.FieldChange{
+				Field: "status", Before: string(models.TaskStatusPending), After: string(models.TaskStatusInProgress),
+			})
+			return nil
+		})
+	}
+
+	task := &models.Task{ID: 4, Title: "Deploy", Status: models.TaskStatusInProgress}
+	err := service.UpdateTask(models.Actor{UserID: 2, Role: models.RoleUser}, task, false)
+	assert.NoError(t, err)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithWebhooks(webhook.NewSender(time.Second), services.DefaultWebhookPolicy))
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	for _, sub := range []models.WebhookSubscription{
+		{URL: "ftp://example.com/hook", Events: []string{models.WebhookTaskCreated}},
+		{URL: "https://example.com/hook"},
+		{URL: "https://example.com/hook", Events: []string{"task.archived"}},
+		{URL: "https://example.com/hook", Events: []string{models.WebhookTaskCreated}, Secret: "short"},
+	} {
+		_, err := service.CreateWebhook(actor, &sub)
+		assert.ErrorIs(t, err, services.ErrInvalidWebhook, sub)
+	}
+
+	repoMock.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(sub *models.WebhookSubscription) error {
+		assert.Equal(t, uint(2), sub.UserID)
+		assert.True(t, sub.Active)
+		sub.ID = 1
+		return nil
+	})
+	created, err := service.CreateWebhook(actor, &models.WebhookSubscription{
+		URL:    " https://example.com/hook ",
+		Events: []string{models.WebhookTaskCreated, models.WebhookTaskCreated, models.WebhookTaskDeleted},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), created.ID)
+	assert.Equal(t, "https://example.com/hook", created.URL)
+	assert.Equal(t, []string{models.WebhookTaskCreated, models.WebhookTaskDeleted}, created.Events)
+	assert.Len(t, created.Secret, 64)
+}
+
+func TestWebhooks_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default())
+
+	_, err := service.CreateWebhook(models.Actor{UserID: 2}, &models.WebhookSubscription{URL: "https://example.com/hook"})
+	assert.ErrorIs(t, err, services.ErrWebhooksDisabled)
+	delivered, err := service.DeliverWebhooks(context.Background())
+	assert.NoError(t, err)
+	assert.Zero(t, delivered)
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		assert.True(t, webhook.Verify("s3cret-s3cret-s3cret", timestamp, body, r.Header.Get(webhook.HeaderSignature), time.Minute))
+		received = append(received, r.Header.Get(webhook.HeaderDelivery))
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	policy := services.DefaultWebhookPolicy
+	policy.MaxAttempts = 3
+	loopback, _ := webhook.ParseNetworks("127.0.0.0/8")
+	service := services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithWebhooks(webhook.NewSender(time.Second, loopback...), policy))
+
+	repoMock.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any(), policy.BatchSize).Return([]models.WebhookDelivery{
+		{ID: 10, SubscriptionID: 1, Event: models.WebhookTaskCreated, Payload: []byte(`{}`), Status: models.WebhookDeliveryPending, Attempts: 0},
+		{ID: 11, SubscriptionID: 2, Event: models.WebhookTaskCreated, Payload: []byte(`{}`), Status: models.WebhookDeliveryPending, Attempts: 1},
+		{ID: 12, SubscriptionID: 2, Event: models.WebhookTaskCreated, Payload: []byte(`{}`), Status: models.WebhookDeliveryPending, Attempts: 2},
+		{ID: 13, SubscriptionID: 3, Event: models.WebhookTaskCreated, Payload: []byte(`{}`), Status: models.WebhookDeliveryPending},
+	}, nil)
+	secret := "s3cret-s3cret-s3cret"
+	repoMock.EXPECT().GetWebhookByID(uint(1)).Return(&models.WebhookSubscription{ID: 1, URL: server.URL + "/up", Secret: secret, Active: true}, nil)
+	repoMock.EXPECT().GetWebhookByID(uint(2)).Return(&models.WebhookSubscription{ID: 2, URL: server.URL + "/down", Secret: secret, Active: true}, nil)
+	repoMock.EXPECT().GetWebhookByID(uint(3)).Return(nil, models.ErrWebhookNotFound)
+
+	updates := map[uint]models.WebhookDelivery{}
+	repoMock.EXPECT().UpdateWebhookDelivery(gomock.Any()).DoAndReturn(func(delivery *models.WebhookDelivery) error {
+		updates[delivery.ID] = *delivery
+		return nil
+	}).Times(4)
+
+	start := time.Now()
+	delivered, err := service.DeliverWebhooks(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, []string{"10", "11", "12"}, received)
+
+	assert.Equal(t, models.WebhookDeliveryDelivered, updates[10].Status)
+	assert.Equal(t, http.StatusOK, updates[10].LastStatusCode)
+	assert.NotNil(t, updates[10].DeliveredAt)
+
+	// The second attempt waits twice the backoff
+	assert.Equal(t, models.WebhookDeliveryPending, updates[11].Status)
+	assert.Equal(t, 2, updates[11].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, updates[11].LastStatusCode)
+	assert.Equal(t, "receiver answered 503 Service Unavailable", updates[11].LastError)
+	assert.WithinDuration(t, start.Add(2*policy.Backoff), *updates[11].NextAttemptAt, time.Second)
+
+	assert.Equal(t, models.WebhookDeliveryFailed, updates[12].Status)
+	assert.Equal(t, 3, updates[12].Attempts)
+	assert.Nil(t, updates[12].NextAttemptAt)
+
+	assert.Equal(t, models.WebhookDeliveryFailed, updates[13].Status)
+	assert.Zero(t, updates[13].Attempts)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := mocks.NewMockTaskRepoInter(ctrl)
+	service := services.NewTaskService(repoMock, nil, log.Default(),
+		services.WithWebhooks(webhook.NewSender(time.Second), services.DefaultWebhookPolicy))
+	actor := models.Actor{UserID: 2, Role: models.RoleUser}
+
+	repoMock.EXPECT().GetWebhookByID(uint(1)).Return(&models.WebhookSubscription{ID: 1, UserID: 2}, nil).Times(2)
+	repoMock.EXPECT().GetWebhookDeliveryByID(uint(10)).Return(&models.WebhookDelivery{
+		ID: 10, SubscriptionID: 1, Event: models.WebhookTaskDeleted, Payload: []byte(`{"event":"task.deleted"}`),
+		Status: models.WebhookDeliveryFailed, Attempts: 8,
+	}, nil)
+	repoMock.EXPECT().CreateWebhookDeliveries(gomock.Any()).DoAndReturn(func(deliveries []models.WebhookDelivery) error {
+		deliveries[0].ID = 11
+		return nil
+	})
+	delivery, err := service.RedeliverWebhook(actor, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(11), delivery.ID)
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+	assert.Equal(t, uint(10), *delivery.RedeliveryOf)
+	assert.JSONEq(t, `{"event":"task.deleted"}`, string(delivery.Payload))
+
+	// Deliveries of other subscriptions are not found
+	repoMock.EXPECT().GetWebhookDeliveryByID(uint(20)).Return(&models.WebhookDelivery{ID: 20, SubscriptionID: 5}, nil)
+	_, err = service.RedeliverWebhook(actor, 1, 20)
+	assert.ErrorIs(t, err, models.ErrWebhookDeliveryNotFound)
+
+	// Nor are other users' subscriptions
+	repoMock.EXPECT().GetWebhookByID(uint(3)).Return(&models.WebhookSubscription{ID: 3, UserID: 9}, nil)
+	_, err = service.RedeliverWebhook(actor, 3, 30)
+	assert.ErrorIs(t, err, models.ErrWebhookNotFound)
 }
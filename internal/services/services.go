@@ -1,28 +1,96 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/ratheeshkumar25/task-mgt/config"
+	"github.com/ratheeshkumar25/task-mgt/internal/blobstore"
+	"github.com/ratheeshkumar25/task-mgt/internal/mailer"
 	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	"github.com/ratheeshkumar25/task-mgt/internal/notifier"
 	repoIface "github.com/ratheeshkumar25/task-mgt/internal/repositories/interfaces"
 	inter "github.com/ratheeshkumar25/task-mgt/internal/services/interfaces"
+	"github.com/ratheeshkumar25/task-mgt/internal/webhook"
 	"github.com/ratheeshkumar25/task-mgt/utility"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type TaskServices struct {
-	Repo   repoIface.TaskRepoInter
-	redis  *config.RedisService
-	Logger *log.Logger
+	Repo     repoIface.TaskRepoInter
+	redis    *config.RedisService
+	tokens   *utility.TokenStore
+	mailer   mailer.Mailer
+	baseURL  string
+	guard    *loginGuard
+	keys     *utility.KeySet
+	subtasks SubtaskPolicy
+	// trashRetention is how long deleted tasks stay in the trash, zero keeps them
+	trashRetention time.Duration
+	blobs          blobstore.BlobStore
+	attachments    AttachmentPolicy
+	reminderPolicy ReminderPolicy
+	reminders      notifier.Multi
+	webhooks       *webhook.Sender
+	webhookPolicy  WebhookPolicy
+	Logger         *log.Logger
 }
 
+// Option configures optional collaborators of TaskServices
+type Option func(*TaskServices)
+
+// WithMailer sets the mailer used for account emails and the base URL of the links they contain
+func WithMailer(m mailer.Mailer, baseURL string) Option {
+	return func(t *TaskServices) {
+		t.mailer = m
+		t.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithKeySet sets the keys tokens are signed and verified with. Without it the
+// JWTKEY secret is used as a single HS256 key.
+func WithKeySet(keys *utility.KeySet) Option {
+	return func(t *TaskServices) {
+		t.keys = keys
+	}
+}
+
+// WithLoginPolicy overrides DefaultLoginPolicy for failed login throttling
+func WithLoginPolicy(policy LoginPolicy) Option {
+	return func(t *TaskServices) {
+		t.guard.policy = policy
+	}
+}
+
+// Errors returned by the token refresh flow
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReuse   = errors.New("refresh token reuse detected, session revoked")
+)
+
+// ErrInvalidCredentials is returned for unknown users and wrong passwords alike,
+// so login responses do not reveal which accounts exist
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// dummyHash is compared against when the user does not exist, keeping the
+// response time of unknown and known usernames the same
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("task-mgt-dummy-password"), bcrypt.DefaultCost)
+
+// Errors returned by LoginUser for accounts an admin has locked down
+var (
+	ErrAccountDisabled       = errors.New("account is disabled")
+	ErrPasswordResetRequired = errors.New("password reset required")
+)
+
 // Regular expression for email validation
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
@@ -42,81 +110,335 @@ func (t *TaskServices) CreateUser(user *models.Users) error {
 		return err
 	}
 	user.PasswordHash = string(hash)
-	return t.Repo.CreateUser(user)
+	// Roles and account state are managed by the server, never at self-registration
+	user.Role = models.RoleUser
+	user.ManagerID = nil
+	user.Disabled = false
+	user.MustResetPassword = false
+	user.EmailVerified = false
+	user.EmailVerifiedAt = nil
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	if err := t.Repo.CreateUser(user); err != nil {
+		return err
+	}
+
+	registered := *user
+	go func() {
+		if err := t.sendVerificationEmail(&registered); err != nil {
+			t.Logger.Println("failed to send verification email:", err)
+		}
+	}()
+	return nil
 }
 
-// LoginUser: Authenticates user and generates JWT token
-func (t *TaskServices) LoginUser(username string, password string) (string, error) {
+// LoginUser: Authenticates user and generates an access and refresh token pair, or an
+// MFA challenge when the user has 2FA enabled.
+// Failed attempts are counted per username and per client IP, see loginGuard.
+func (t *TaskServices) LoginUser(username string, password string, clientIP string) (*utility.LoginResult, error) {
+	if err := t.guard.Check(username, clientIP); err != nil {
+		return nil, err
+	}
+
 	foundUser, err := t.Repo.GetUserByUsername(username)
 	if err != nil {
-		return "", errors.New("user not found")
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		t.guard.Fail(username, clientIP)
+		return nil, ErrInvalidCredentials
 	}
 
 	if bcrypt.CompareHashAndPassword([]byte(foundUser.PasswordHash), []byte(password)) != nil {
-		return "", errors.New("invalid credentials")
+		t.guard.Fail(username, clientIP)
+		return nil, ErrInvalidCredentials
+	}
+	if foundUser.Disabled {
+		return nil, ErrAccountDisabled
 	}
+	if foundUser.MustResetPassword {
+		return nil, ErrPasswordResetRequired
+	}
+	if foundUser.TOTPEnabled {
+		// The counters are only cleared once the second factor checks out too
+		return t.mfaChallenge(foundUser)
+	}
+	t.guard.Succeed(username)
 
-	cfg := config.LoadConfig()
-	token, err := utility.GenerateToken(cfg.SECERETKEY, foundUser.Username, foundUser.ID)
+	tokens, err := t.issueTokens(foundUser, "")
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return token, nil
+	return &utility.LoginResult{TokenPair: tokens}, nil
 }
 
-// CreateTask: Creates a task and clears Redis cache asynchronously
-func (t *TaskServices) CreateTask(task *models.Task) error {
-	err := t.Repo.CreateTask(task)
-	if err == nil {
+// issueTokens signs a token pair for the user and registers the refresh token.
+// Passing the family of an existing session rotates it instead of starting a new one.
+func (t *TaskServices) issueTokens(user *models.Users, family string) (*utility.TokenPair, error) {
+	pair, err := utility.GenerateTokenPair(t.keys, user.Username, user.ID, string(user.Role), family)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.tokens.SaveRefreshToken(context.Background(), pair, user.ID); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// RefreshToken: Exchanges a refresh token for a new pair. Each refresh token is
+// single use; presenting one twice revokes the whole session family.
+func (t *TaskServices) RefreshToken(refreshToken string) (*utility.TokenPair, error) {
+	ctx := context.Background()
+
+	claims, err := utility.ParseToken(t.keys, refreshToken)
+	if err != nil || claims.TokenType != utility.RefreshToken {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	revoked, err := t.tokens.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	consumed, err := t.tokens.ConsumeRefreshToken(ctx, claims.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		if revErr := t.tokens.RevokeFamily(ctx, claims.Family); revErr != nil {
+			t.Logger.Println("failed to revoke token family:", revErr)
+		}
+		t.Logger.Printf("refresh token reuse for user %d, family %s revoked", claims.UserID, claims.Family)
+		return nil, ErrRefreshTokenReuse
+	}
+
+	user, err := t.VerifyTokenUser(claims)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	return t.issueTokens(user, claims.Family)
+}
+
+// VerifyTokenUser: Loads the user a token was issued to and checks the account is
+// still usable. Rejections are *utility.TokenError values, lookup failures are not.
+func (t *TaskServices) VerifyTokenUser(claims *utility.UserClaim) (*models.Users, error) {
+	user, err := t.Repo.FindUserByID(claims.UserID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, utility.NewTokenError(utility.ErrCodeUserNotFound, "token user no longer exists")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Username != claims.Username {
+		return nil, utility.NewTokenError(utility.ErrCodeTokenPayload, "token payload does not match its user")
+	}
+	if user.Disabled {
+		return nil, utility.NewTokenError(utility.ErrCodeUserDisabled, "account is disabled")
+	}
+	return user, nil
+}
+
+// Logout: Revokes the presented access token and every other token of its session
+func (t *TaskServices) Logout(claims *utility.UserClaim) error {
+	ctx := context.Background()
+	if err := t.tokens.RevokeToken(ctx, claims.Id, time.Unix(claims.ExpiresAt, 0)); err != nil {
+		return err
+	}
+	return t.tokens.RevokeFamily(ctx, claims.Family)
+}
+
+// LogoutAll: Revokes every token issued to the user so far
+func (t *TaskServices) LogoutAll(userID uint) error {
+	return t.tokens.RevokeAllForUser(context.Background(), userID)
+}
+
+// taskListPattern matches every cached list page of a single user.
+func taskListPattern(userID uint) string {
+	return fmt.Sprintf("tasks_list:user=%d:*", userID)
+}
+
+// taskKey is the cache key of a single task.
+func taskKey(id uint) string {
+	return fmt.Sprintf("task:id=%d", id)
+}
+
+// invalidateTaskCache clears the owner's cached lists and, when id is set, the cached
+// task. The lists of every member of the given projects are cleared as well, they
+// include the projects' tasks whoever owns them.
+func (t *TaskServices) invalidateTaskCache(userID, id uint, projectIDs ...*uint) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		if delErr := t.redis.DeleteByPattern(taskListPattern(userID)); delErr != nil {
+			t.Logger.Println("Redis delete error:", delErr)
+		}
+	}()
+
+	if id != 0 {
+		wg.Add(1)
 		go func() {
-			if delErr := t.redis.DeleteFromRedis("tasks_list"); delErr != nil {
+			defer wg.Done()
+			if delErr := t.redis.DeleteFromRedis(taskKey(id)); delErr != nil {
 				t.Logger.Println("Redis delete error:", delErr)
 			}
 		}()
 	}
+
+	for _, projectID := range projectIDs {
+		if projectID == nil || t.redis == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(projectID uint) {
+			defer wg.Done()
+			t.invalidateProjectLists(projectID)
+		}(*projectID)
+	}
+
+	wg.Wait()
+}
+
+// invalidateProjectLists clears the cached lists of every member of the project
+func (t *TaskServices) invalidateProjectLists(projectID uint) {
+	project, err := t.Repo.GetProjectByID(projectID)
+	if err != nil {
+		t.Logger.Println("failed to load project members:", err)
+		return
+	}
+	for _, memberID := range project.MemberIDs {
+		if delErr := t.redis.DeleteByPattern(taskListPattern(memberID)); delErr != nil {
+			t.Logger.Println("Redis delete error:", delErr)
+		}
+	}
+}
+
+// invalidateSharedTasks clears the cached tasks and, as they may show up in anyone's
+// lists, every cached task list
+func (t *TaskServices) invalidateSharedTasks(taskIDs []uint) {
+	if delErr := t.redis.DeleteByPattern("tasks_list:*"); delErr != nil {
+		t.Logger.Println("Redis delete error:", delErr)
+	}
+	for _, id := range taskIDs {
+		if delErr := t.redis.DeleteFromRedis(taskKey(id)); delErr != nil {
+			t.Logger.Println("Redis delete error:", delErr)
+		}
+	}
+}
+
+// CreateTask: Creates a task for task.UserID and clears Redis cache asynchronously.
+// Tasks can only be put on projects the owner is a member of.
+func (t *TaskServices) CreateTask(task *models.Task) error {
+	if task.UserID == 0 {
+		return errors.New("task owner is required")
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+	if !models.IsValidPriority(task.Priority) {
+		return fmt.Errorf("invalid priority %q", task.Priority)
+	}
+	workflow, err := t.Repo.GetWorkflow()
+	if err != nil {
+		return err
+	}
+	if err := checkStatus(workflow, task); err != nil {
+		return err
+	}
+	if err := checkReminders(task); err != nil {
+		return err
+	}
+	// Tasks start a series of their own, later occurrences are created on completion
+	task.SeriesID, task.Occurrence = nil, 0
+	if err := checkRecurrence(task); err != nil {
+		return err
+	}
+	owner := models.Actor{UserID: task.UserID, Role: models.RoleUser}
+	if task.ProjectID != nil {
+		if err := t.checkTaskProject(owner, *task.ProjectID); err != nil {
+			return err
+		}
+	}
+	task.ParentID = resolveParent(task.ParentID, nil)
+	if task.ParentID != nil {
+		if err := t.checkTaskParent(owner, task, *task.ParentID, 0); err != nil {
+			return err
+		}
+	}
+	err = t.Repo.CreateTask(task)
+	if err == nil {
+		t.recordHistory(task.UserID, task, models.HistoryCreated, taskDiff(nil, task))
+		t.notifyMentions(task.UserID, task, nil, mentions(task.Description))
+		go t.invalidateTaskCache(task.UserID, 0, task.ProjectID)
+		go t.invalidateAncestors(task.ParentID)
+	}
 	return err
 }
 
-// GetAllTasks: Fetches tasks with caching support
-func (t *TaskServices) GetAllTasks(status string, dueDateAfter string, sortBy string, sortOrder string, page int, limit int) ([]models.Task, int64, error) {
-	cacheKey := fmt.Sprintf("tasks_list:status=%s:dueAfter=%s:sortBy=%s:order=%s:page=%d:limit=%d",
-		status, dueDateAfter, sortBy, sortOrder, page, limit)
+// GetAllTasks: Fetches the tasks visible to the actor. Only lists limited to the
+// actor's own tasks are cached, wider scopes change with every team member's edit.
+func (t *TaskServices) GetAllTasks(actor models.Actor, filter models.TaskFilter) ([]models.Task, int64, error) {
+	owners, err := t.ownerScope(actor)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.OwnerIDs = owners
+	// Project tasks are listed by membership, whoever owns them
+	if owners != nil {
+		filter.MemberID = actor.UserID
+	}
+	cacheable := len(owners) == 1 && owners[0] == actor.UserID
+
+	blocked := ""
+	if filter.Blocked != nil {
+		blocked = strconv.FormatBool(*filter.Blocked)
+	}
+	cacheKey := fmt.Sprintf("tasks_list:user=%d:project=%d:assignee=%d:status=%s:priority=%v:labels=%v:%s:blocked=%s:dueAfter=%s:sortBy=%s:order=%s:page=%d:limit=%d",
+		actor.UserID, filter.ProjectID, filter.AssigneeID, filter.Status, filter.Priorities, filter.Labels, filter.LabelMode, blocked,
+		filter.DueDateAfter, filter.SortBy, filter.SortOrder, filter.Page, filter.Limit)
 
 	// Try to get from Redis
-	cachedData, err := t.redis.GetFromRedis(cacheKey)
-	if err == nil && cachedData != "" {
-		var cachedTasks []models.TaskWithTotal
-		if jsonErr := json.Unmarshal([]byte(cachedData), &cachedTasks); jsonErr == nil && len(cachedTasks) > 0 {
-			return cachedTasks[0].Tasks, cachedTasks[0].Total, nil
+	if cacheable {
+		cachedData, err := t.redis.GetFromRedis(cacheKey)
+		if err == nil && cachedData != "" {
+			var cachedTasks []models.TaskWithTotal
+			if jsonErr := json.Unmarshal([]byte(cachedData), &cachedTasks); jsonErr == nil && len(cachedTasks) > 0 {
+				return cachedTasks[0].Tasks, cachedTasks[0].Total, nil
+			}
 		}
 	}
 
 	// Fetch from DB if not cached
-	tasks, total, err := t.Repo.GetFilteredTasks(status, dueDateAfter, sortBy, sortOrder, page, limit)
+	tasks, total, err := t.Repo.GetFilteredTasks(filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Store result in Redis
-	go func() {
-		cacheData := []models.TaskWithTotal{{Tasks: tasks, Total: total}}
-		jsonData, _ := json.Marshal(cacheData)
-		_ = t.redis.SetDataInRedis(cacheKey, jsonData, 5*time.Minute)
-	}()
+	if cacheable {
+		go func() {
+			cacheData := []models.TaskWithTotal{{Tasks: tasks, Total: total}}
+			jsonData, _ := json.Marshal(cacheData)
+			_ = t.redis.SetDataInRedis(cacheKey, jsonData, 5*time.Minute)
+		}()
+	}
 
 	return tasks, total, nil
 }
 
-// GetTaskByID: Retrieves a single task, caches it for quick access
-func (t *TaskServices) GetTaskByID(id uint) (*models.Task, error) {
-	key := fmt.Sprintf("task_%d", id)
+// loadTask fetches a task through the cache without any access check
+func (t *TaskServices) loadTask(id uint) (*models.Task, error) {
+	key := taskKey(id)
 
 	// Try to fetch from cache
 	cachedData, err := t.redis.GetFromRedis(key)
 	if err == nil && cachedData != "" {
-		var task models.Task
-		json.Unmarshal([]byte(cachedData), &task)
-		return &task, nil
+		var cached models.Task
+		if jsonErr := json.Unmarshal([]byte(cachedData), &cached); jsonErr == nil {
+			return &cached, nil
+		}
 	}
 
 	// Fetch from DB if not found in cache
@@ -134,67 +456,182 @@ func (t *TaskServices) GetTaskByID(id uint) (*models.Task, error) {
 	return task, nil
 }
 
-// UpdateTask: Updates a task and clears Redis cache concurrently
-func (t *TaskServices) UpdateTask(task *models.Task) error {
-	err := t.Repo.UpdateTask(task)
+// authorizedTask loads a task and checks the permission matrix. Tasks the actor may
+// not touch are reported as ErrTaskNotFound so their existence is not revealed.
+func (t *TaskServices) authorizedTask(actor models.Actor, action TaskAction, id uint) (*models.Task, error) {
+	task, err := t.loadTask(id)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := t.canAccessTask(actor, action, task)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, models.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// GetTaskByID: Retrieves a single task the actor may read
+func (t *TaskServices) GetTaskByID(actor models.Actor, id uint) (*models.Task, error) {
+	return t.authorizedTask(actor, ActionReadTask, id)
+}
+
+// UpdateTask: Updates a task the actor may modify and clears Redis cache concurrently.
+// Status changes follow the workflow, and blocked tasks can only be started with force.
+func (t *TaskServices) UpdateTask(actor models.Actor, task *models.Task, force bool) error {
+	existing, err := t.authorizedTask(actor, ActionUpdateTask, task.ID)
+	if err != nil {
+		return err
+	}
+	// Clients that leave the project out keep the current one, 0 takes the task
+	// off its project
+	if task.ProjectID == nil {
+		task.ProjectID = existing.ProjectID
+	} else if *task.ProjectID == 0 {
+		task.ProjectID = nil
+	}
+	if task.ProjectID != nil && (existing.ProjectID == nil || *existing.ProjectID != *task.ProjectID) {
+		if err := t.checkTaskProject(actor, *task.ProjectID); err != nil {
+			return err
+		}
+	}
+	// Clients that do not know about priorities keep the current one
+	if task.Priority == "" {
+		task.Priority = existing.Priority
+	}
+	if task.Priority == "" {
+		task.Priority = models.TaskPriorityMedium
+	}
+	if !models.IsValidPriority(task.Priority) {
+		return fmt.Errorf("invalid priority %q", task.Priority)
+	}
+	if err := t.checkUpdatedParent(actor, existing, task); err != nil {
+		return err
+	}
+	// Clients that leave the due date out keep the current one
+	if task.DueDate == nil {
+		task.DueDate = existing.DueDate
+	}
+	// Clients that leave the reminders out keep the current ones, an empty list
+	// goes back to the default reminders
+	if task.ReminderMinutes == nil {
+		task.ReminderMinutes = existing.ReminderMinutes
+	}
+	if err := checkReminders(task); err != nil {
+		return err
+	}
+	// Clients that leave the recurrence out keep the current one, the series is
+	// stopped through StopRecurrence
+	if task.Recurrence == "" {
+		task.Recurrence = existing.Recurrence
+	}
+	task.SeriesID, task.Occurrence = existing.SeriesID, existing.Occurrence
+	if err := checkRecurrence(task); err != nil {
+		return err
+	}
+	// Clients that leave the status out keep the current one
+	if task.Status == "" {
+		task.Status = existing.Status
+	}
+	workflow, err := t.Repo.GetWorkflow()
+	if err != nil {
+		return err
+	}
+	if err := checkTransition(workflow, existing.Status, task.Status); err != nil {
+		return err
+	}
+	if entering(workflow, existing.Status, task.Status, models.StatusCategoryDone) {
+		if err := t.checkSubtasksCompleted(task.ID); err != nil {
+			return err
+		}
+	}
+	if entering(workflow, existing.Status, task.Status, models.StatusCategoryDoing) && !force {
+		if err := t.checkNotBlocked(task.ID); err != nil {
+			return err
+		}
+	}
+	task.UserID = existing.UserID
+	task.CreatedAt = existing.CreatedAt
+	task.AssigneeIDs = existing.AssigneeIDs
+	task.Labels = existing.Labels
+	task.DependsOn = existing.DependsOn
+	task.Blocked = existing.Blocked
+	err = t.Repo.UpdateTask(task)
 	if err == nil {
-		go func(id uint) {
-			var wg sync.WaitGroup
-			wg.Add(2)
-
-			go func() {
-				defer wg.Done()
-				if delErr := t.redis.DeleteFromRedis("tasks_list"); delErr != nil {
-					t.Logger.Println("Redis delete error:", delErr)
-				}
-			}()
-
-			go func() {
-				defer wg.Done()
-				if delErr := t.redis.DeleteFromRedis(fmt.Sprintf("task_%d", id)); delErr != nil {
-					t.Logger.Println("Redis delete error:", delErr)
-				}
-			}()
-
-			wg.Wait()
-		}(task.ID)
+		t.recordHistory(actor.UserID, task, models.HistoryUpdated, taskDiff(existing, task))
+		t.notifyMentions(actor.UserID, task, nil, newMentions(existing.Description, task.Description))
+		if existing.Status != task.Status {
+			t.notifyStatus(actor.UserID, task, existing.Status, task.Status)
+		}
+		go t.invalidateTaskCache(existing.UserID, task.ID, existing.ProjectID, task.ProjectID)
+		go t.invalidateAncestors(existing.ParentID)
+		if !sameID(existing.ParentID, task.ParentID) {
+			go t.invalidateAncestors(task.ParentID)
+		}
+		if existing.Status != task.Status {
+			go t.invalidateDependents(task.ID)
+		}
+		if task.Recurrence != "" && entering(workflow, existing.Status, task.Status, models.StatusCategoryDone) {
+			t.createNextOccurrence(actor.UserID, task, workflow)
+		}
 	}
 	return err
 }
 
-// DeleteTask: Deletes a task and clears Redis cache concurrently
-func (t *TaskServices) DeleteTask(id uint) error {
-	err := t.Repo.DeleteTask(id)
+// DeleteTask: Moves a task the actor may delete to its owner's trash and clears Redis cache concurrently
+func (t *TaskServices) DeleteTask(actor models.Actor, id uint) error {
+	existing, err := t.authorizedTask(actor, ActionDeleteTask, id)
+	if err != nil {
+		return err
+	}
+	// Subtasks move up to the parent, their cached copies go stale
+	var subtasks []models.Task
+	if t.redis != nil {
+		if subtasks, err = t.Repo.GetSubtasks(id); err != nil {
+			return err
+		}
+	}
+	err = t.Repo.DeleteTask(existing.UserID, id)
 	if err == nil {
-		go func(id uint) {
-			var wg sync.WaitGroup
-			wg.Add(2)
-
-			go func() {
-				defer wg.Done()
-				if delErr := t.redis.DeleteFromRedis("tasks_list"); delErr != nil {
-					t.Logger.Println("Redis delete error:", delErr)
-				}
-			}()
-
-			go func() {
-				defer wg.Done()
-				if delErr := t.redis.DeleteFromRedis(fmt.Sprintf("task_%d", id)); delErr != nil {
-					t.Logger.Println("Redis delete error:", delErr)
-				}
-			}()
-
-			wg.Wait()
-		}(id)
+		t.recordHistory(actor.UserID, existing, models.HistoryDeleted, []models.FieldChange{{Field: "title", Before: existing.Title}})
+		go t.invalidateTaskCache(existing.UserID, id, existing.ProjectID)
+		go t.invalidateAncestors(existing.ParentID)
+		go t.invalidateDependents(id)
+		for _, subtask := range subtasks {
+			go t.invalidateTaskCache(subtask.UserID, subtask.ID)
+		}
 	}
 	return err
 }
 
 // NewTaskService: Constructor function
-func NewTaskService(repo repoIface.TaskRepoInter, redis *config.RedisService, logger *log.Logger) inter.TaskServiceInter {
-	return &TaskServices{
-		Repo:   repo,
-		redis:  redis,
-		Logger: logger,
+func NewTaskService(repo repoIface.TaskRepoInter, redis *config.RedisService, logger *log.Logger, opts ...Option) inter.TaskServiceInter {
+	svc := &TaskServices{
+		Repo:           repo,
+		redis:          redis,
+		mailer:         mailer.NewLogMailer(logger),
+		subtasks:       DefaultSubtaskPolicy,
+		trashRetention: DefaultTrashRetention,
+		attachments:    DefaultAttachmentPolicy,
+		reminderPolicy: DefaultReminderPolicy,
+		Logger:         logger,
+	}
+	var client *goredis.Client
+	if redis != nil {
+		client = redis.Client
+		svc.tokens = utility.NewTokenStore(client)
+	}
+	svc.guard = newLoginGuard(client, DefaultLoginPolicy, logger.Println)
+	for _, opt := range opts {
+		opt(svc)
+	}
+	if svc.keys == nil {
+		svc.keys = utility.NewHMACKeySet(config.LoadConfig().SECERETKEY)
+	}
+	if svc.reminderPolicy.InApp {
+		svc.reminders = append(notifier.Multi{notifier.Func(svc.inAppReminders)}, svc.reminders...)
 	}
+	return svc
 }
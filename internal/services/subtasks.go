@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// SubtaskPolicy controls how tasks can be nested
+type SubtaskPolicy struct {
+	// MaxDepth is the number of subtask levels allowed below a top level task
+	MaxDepth int
+	// BlockParentCompletion keeps a task out of the done statuses while subtasks are open
+	BlockParentCompletion bool
+}
+
+// DefaultSubtaskPolicy is used unless WithSubtaskPolicy overrides it
+var DefaultSubtaskPolicy = SubtaskPolicy{
+	MaxDepth:              5,
+	BlockParentCompletion: true,
+}
+
+// WithSubtaskPolicy overrides DefaultSubtaskPolicy
+func WithSubtaskPolicy(policy SubtaskPolicy) Option {
+	return func(t *TaskServices) {
+		t.subtasks = policy
+	}
+}
+
+// Errors returned when a task is put under a parent
+var (
+	ErrParentNotFound    = errors.New("parent task not found")
+	ErrParentScope       = errors.New("a subtask must be on the same project as its parent, or have the same owner")
+	ErrSubtaskCycle      = errors.New("a task cannot be put under itself or one of its subtasks")
+	ErrSubtaskDepth      = errors.New("subtasks are nested too deep")
+	ErrOpenSubtasks      = errors.New("the task has open subtasks")
+	ErrSubtasksInProject = errors.New("move the subtasks off the task before moving it to another project")
+)
+
+// resolveParent turns the parentId sent by clients into the stored value: nil
+// keeps the current parent, 0 makes the task a top level task
+func resolveParent(requested, current *uint) *uint {
+	if requested == nil {
+		return current
+	}
+	if *requested == 0 {
+		return nil
+	}
+	return requested
+}
+
+// sameID reports whether both optional ids are unset or equal
+func sameID(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// checkTaskParent checks that the task may go under the parent: the actor must be
+// able to modify the parent, both must share the project or owner, and the move
+// must neither close a cycle nor exceed the depth limit. height is the number of
+// subtask levels the task already has.
+func (t *TaskServices) checkTaskParent(actor models.Actor, task *models.Task, parentID uint, height int) error {
+	if parentID == task.ID {
+		return ErrSubtaskCycle
+	}
+	parent, err := t.authorizedTask(actor, ActionUpdateTask, parentID)
+	if errors.Is(err, models.ErrTaskNotFound) {
+		return ErrParentNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !sameID(parent.ProjectID, task.ProjectID) || (task.ProjectID == nil && parent.UserID != task.UserID) {
+		return ErrParentScope
+	}
+	ancestors, err := t.Repo.GetTaskAncestorIDs(parentID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ancestors {
+		if id == task.ID {
+			return ErrSubtaskCycle
+		}
+	}
+	if len(ancestors)+1+height > t.subtasks.MaxDepth {
+		return fmt.Errorf("%w, at most %d levels are allowed", ErrSubtaskDepth, t.subtasks.MaxDepth)
+	}
+	return nil
+}
+
+// checkSubtasksCompleted rejects moving a task to a done status while its subtasks are open
+func (t *TaskServices) checkSubtasksCompleted(id uint) error {
+	if !t.subtasks.BlockParentCompletion {
+		return nil
+	}
+	progress, err := t.Repo.GetSubtaskProgress([]uint{id})
+	if err != nil {
+		return err
+	}
+	if p, ok := progress[id]; ok && p.Completed < p.Total {
+		return fmt.Errorf("%w: %d of %d completed", ErrOpenSubtasks, p.Completed, p.Total)
+	}
+	return nil
+}
+
+// invalidateAncestors clears the cached parent chain starting at parentID, their
+// progress changes with the subtask
+func (t *TaskServices) invalidateAncestors(parentID *uint) {
+	if parentID == nil || t.redis == nil {
+		return
+	}
+	ancestors, err := t.Repo.GetTaskAncestorIDs(*parentID)
+	if err != nil {
+		t.Logger.Println("failed to load parent tasks:", err)
+		return
+	}
+	for _, id := range append([]uint{*parentID}, ancestors...) {
+		if delErr := t.redis.DeleteFromRedis(taskKey(id)); delErr != nil {
+			t.Logger.Println("Redis delete error:", delErr)
+		}
+	}
+}
+
+// GetSubtasks: Lists the direct subtasks of a task the actor may read, together
+// with the progress of all its subtasks
+func (t *TaskServices) GetSubtasks(actor models.Actor, id uint) ([]models.Task, *models.TaskProgress, error) {
+	if _, err := t.authorizedTask(actor, ActionReadTask, id); err != nil {
+		return nil, nil, err
+	}
+	subtasks, err := t.Repo.GetSubtasks(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	progress, err := t.Repo.GetSubtaskProgress([]uint{id})
+	if err != nil {
+		return nil, nil, err
+	}
+	p, ok := progress[id]
+	if !ok {
+		return subtasks, &models.TaskProgress{}, nil
+	}
+	return subtasks, &p, nil
+}
+
+// checkUpdatedParent resolves the parent of an updated task and validates it when
+// the parent or the project changes
+func (t *TaskServices) checkUpdatedParent(actor models.Actor, existing, task *models.Task) error {
+	task.ParentID = resolveParent(task.ParentID, existing.ParentID)
+	projectChanged := !sameID(existing.ProjectID, task.ProjectID)
+	if !projectChanged && sameID(existing.ParentID, task.ParentID) {
+		return nil
+	}
+	height, err := t.Repo.GetSubtaskHeight(task.ID)
+	if err != nil {
+		return err
+	}
+	if projectChanged && height > 0 {
+		return ErrSubtasksInProject
+	}
+	if task.ParentID == nil {
+		return nil
+	}
+	// The owner does not change, the scope check needs it on the new copy
+	candidate := *task
+	candidate.UserID = existing.UserID
+	return t.checkTaskParent(actor, &candidate, *task.ParentID, height)
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	inter "github.com/ratheeshkumar25/task-mgt/internal/services/interfaces"
+)
+
+// DefaultTrashRetention is how long deleted tasks stay in the trash
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// WithTrashRetention overrides DefaultTrashRetention, zero keeps deleted tasks
+// until they are purged by hand
+func WithTrashRetention(retention time.Duration) Option {
+	return func(t *TaskServices) {
+		t.trashRetention = retention
+	}
+}
+
+// GetTrash: Pages through the actor's trash, most recently deleted first
+func (t *TaskServices) GetTrash(actor models.Actor, page, limit int) ([]models.TrashedTask, int64, error) {
+	tasks, total, err := t.Repo.GetTrashedTasks(actor.UserID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	trashed := make([]models.TrashedTask, len(tasks))
+	for i, task := range tasks {
+		trashed[i] = models.TrashedTask{Task: task, DeletedAt: task.DeletedAt.Time}
+		if t.trashRetention > 0 {
+			purgeAt := task.DeletedAt.Time.Add(t.trashRetention)
+			trashed[i].PurgeAt = &purgeAt
+		}
+	}
+	return trashed, total, nil
+}
+
+// trashedTask loads a task in the trash that the actor could have deleted
+func (t *TaskServices) trashedTask(actor models.Actor, id uint) (*models.Task, error) {
+	task, err := t.Repo.GetTrashedTask(id)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := t.canAccessTask(actor, ActionDeleteTask, task)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, models.ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// RestoreTask: Takes a task out of the trash. A status dropped from the workflow
+// meanwhile falls back to the initial status, and a parent that is gone or no
+// longer fits leaves the task top level.
+func (t *TaskServices) RestoreTask(actor models.Actor, id uint) (*models.Task, error) {
+	trashed, err := t.trashedTask(actor, id)
+	if err != nil {
+		return nil, err
+	}
+	restored := *trashed
+	workflow, err := t.Repo.GetWorkflow()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := workflow.Status(restored.Status); !ok {
+		restored.Status = workflow.InitialStatus()
+	}
+	if restored.ParentID != nil {
+		err := t.checkTaskParent(actor, &restored, *restored.ParentID, 0)
+		switch {
+		case errors.Is(err, ErrParentNotFound), errors.Is(err, ErrParentScope),
+			errors.Is(err, ErrSubtaskCycle), errors.Is(err, ErrSubtaskDepth):
+			restored.ParentID = nil
+		case err != nil:
+			return nil, err
+		}
+	}
+	if err := t.Repo.RestoreTask(&restored); err != nil {
+		return nil, err
+	}
+	task, err := t.Repo.GetTaskByID(id)
+	if err != nil {
+		return nil, err
+	}
+	changes := append([]models.FieldChange{{Field: "title", After: task.Title}}, taskDiff(trashed, task)...)
+	t.recordHistory(actor.UserID, task, models.HistoryRestored, changes)
+	go t.invalidateTaskCache(task.UserID, id, task.ProjectID)
+	go t.invalidateAncestors(task.ParentID)
+	go t.invalidateDependents(id)
+	return task, nil
+}
+
+// PurgeTask: Deletes a task in the trash for good. Its history is kept.
+func (t *TaskServices) PurgeTask(actor models.Actor, id uint) error {
+	trashed, err := t.trashedTask(actor, id)
+	if err != nil {
+		return err
+	}
+	if err := t.Repo.PurgeTask(trashed.UserID, id); err != nil {
+		return err
+	}
+	t.sweepAttachments()
+	return nil
+}
+
+// EmptyTrash: Deletes every task in the actor's trash for good
+func (t *TaskServices) EmptyTrash(actor models.Actor) (int64, error) {
+	purged, err := t.Repo.PurgeTrashedTasks(actor.UserID, time.Now())
+	if err == nil && purged > 0 {
+		t.sweepAttachments()
+	}
+	return purged, err
+}
+
+// PurgeExpiredTrash: Deletes the tasks that have been in the trash longer than the
+// retention period for good
+func (t *TaskServices) PurgeExpiredTrash() (int64, error) {
+	if t.trashRetention <= 0 {
+		return 0, nil
+	}
+	purged, err := t.Repo.PurgeTrashedTasks(0, time.Now().Add(-t.trashRetention))
+	if err == nil && purged > 0 {
+		t.sweepAttachments()
+	}
+	return purged, err
+}
+
+// RunTrashPurge calls PurgeExpiredTrash right away and then every interval until
+// ctx is done. Running it on several instances is harmless.
+func RunTrashPurge(ctx context.Context, svc inter.TaskServiceInter, interval time.Duration, logger *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		purged, err := svc.PurgeExpiredTrash()
+		if err != nil {
+			logger.Println("failed to purge expired trash:", err)
+		} else if purged > 0 {
+			logger.Printf("purged %d expired tasks from the trash", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
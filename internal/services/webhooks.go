@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+	inter "github.com/ratheeshkumar25/task-mgt/internal/services/interfaces"
+	"github.com/ratheeshkumar25/task-mgt/internal/webhook"
+)
+
+// WebhookPolicy controls webhook deliveries. Failed attempts are retried after
+// Backoff, doubling up to MaxBackoff, until MaxAttempts have failed.
+type WebhookPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// BatchSize is the number of deliveries a worker run takes on
+	BatchSize int
+	// Lease is how long a claimed delivery is left to its worker
+	Lease time.Duration
+}
+
+// DefaultWebhookPolicy gives up after 8 attempts over roughly two hours
+var DefaultWebhookPolicy = WebhookPolicy{
+	MaxAttempts: 8,
+	Backoff:     30 * time.Second,
+	MaxBackoff:  time.Hour,
+	BatchSize:   20,
+	Lease:       2 * time.Minute,
+}
+
+// minWebhookSecretLength is the shortest secret a client may choose
+const minWebhookSecretLength = 16
+
+// Errors returned by the webhook endpoints
+var (
+	ErrWebhooksDisabled = errors.New("webhooks are not configured")
+	ErrInvalidWebhook   = errors.New("invalid webhook")
+)
+
+// WithWebhooks enables webhook subscriptions, sent through sender. Without it the
+// webhook endpoints are disabled and no events are queued.
+func WithWebhooks(sender *webhook.Sender, policy WebhookPolicy) Option {
+	return func(t *TaskServices) {
+		t.webhooks = sender
+		t.webhookPolicy = policy
+	}
+}
+
+// WebhookPayload is the JSON body of a webhook request
+type WebhookPayload struct {
+	Event      string               `json:"event"`
+	OccurredAt time.Time            `json:"occurred_at"`
+	ActorID    uint                 `json:"actor_id"`
+	Task       *models.Task         `json:"task"`
+	Changes    []models.FieldChange `json:"changes,omitempty"`
+}
+
+// backoff is the wait before the next attempt after attempts failed ones
+func (p WebhookPolicy) backoff(attempts int) time.Duration {
+	wait := p.Backoff
+	for i := 1; i < attempts && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// checkWebhook validates the URL and events of a subscription
+func checkWebhook(sub *models.WebhookSubscription) error {
+	sub.URL = strings.TrimSpace(sub.URL)
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if len(sub.Events) == 0 {
+		return fmt.Errorf("%w: at least one event is required", ErrInvalidWebhook)
+	}
+	seen := make(map[string]bool, len(sub.Events))
+	events := make([]string, 0, len(sub.Events))
+	for _, event := range sub.Events {
+		if !models.IsValidWebhookEvent(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	sub.Events = events
+	return nil
+}
+
+// ownedWebhook loads one of the actor's subscriptions, others are reported as missing
+func (t *TaskServices) ownedWebhook(actor models.Actor, id uint) (*models.WebhookSubscription, error) {
+	if t.webhooks == nil {
+		return nil, ErrWebhooksDisabled
+	}
+	sub, err := t.Repo.GetWebhookByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.UserID != actor.UserID {
+		return nil, models.ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+// CreateWebhook: Subscribes the actor to task events. The secret is generated
+// unless one is given and is only part of this response.
+func (t *TaskServices) CreateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.CreatedWebhook, error) {
+	if t.webhooks == nil {
+		return nil, ErrWebhooksDisabled
+	}
+	if err := checkWebhook(sub); err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		sub.Secret = hex.EncodeToString(secret)
+	} else if len(sub.Secret) < minWebhookSecretLength {
+		return nil, fmt.Errorf("%w: secret must be at least %d characters", ErrInvalidWebhook, minWebhookSecretLength)
+	}
+	sub.ID = 0
+	sub.UserID = actor.UserID
+	sub.Active = true
+	if err := t.Repo.CreateWebhook(sub); err != nil {
+		return nil, err
+	}
+	return &models.CreatedWebhook{WebhookSubscription: *sub, Secret: sub.Secret}, nil
+}
+
+// ListWebhooks: Lists the actor's subscriptions without their secrets
+func (t *TaskServices) ListWebhooks(actor models.Actor) ([]models.WebhookSubscription, error) {
+	if t.webhooks == nil {
+		return nil, ErrWebhooksDisabled
+	}
+	return t.Repo.GetWebhooks(actor.UserID)
+}
+
+// GetWebhook: Retrieves one of the actor's subscriptions
+func (t *TaskServices) GetWebhook(actor models.Actor, id uint) (*models.WebhookSubscription, error) {
+	return t.ownedWebhook(actor, id)
+}
+
+// UpdateWebhook: Changes the URL, events and active flag of one of the actor's
+// subscriptions. The secret stays.
+func (t *TaskServices) UpdateWebhook(actor models.Actor, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	existing, err := t.ownedWebhook(actor, sub.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkWebhook(sub); err != nil {
+		return nil, err
+	}
+	existing.URL = sub.URL
+	existing.Events = sub.Events
+	existing.Active = sub.Active
+	existing.UpdatedAt = time.Now()
+	if err := t.Repo.UpdateWebhook(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteWebhook: Deletes one of the actor's subscriptions along with its delivery log
+func (t *TaskServices) DeleteWebhook(actor models.Actor, id uint) error {
+	if _, err := t.ownedWebhook(actor, id); err != nil {
+		return err
+	}
+	return t.Repo.DeleteWebhook(id)
+}
+
+// ListWebhookDeliveries: Pages through the delivery log of one of the actor's
+// subscriptions, newest first
+func (t *TaskServices) ListWebhookDeliveries(actor models.Actor, id uint, page, limit int) ([]models.WebhookDelivery, int64, error) {
+	if _, err := t.ownedWebhook(actor, id); err != nil {
+		return nil, 0, err
+	}
+	return t.Repo.GetWebhookDeliveries(id, page, limit)
+}
+
+// RedeliverWebhook: Queues a delivery of one of the actor's subscriptions again,
+// as a new entry of its log with the original payload
+func (t *TaskServices) RedeliverWebhook(actor models.Actor, id, deliveryID uint) (*models.WebhookDelivery, error) {
+	if _, err := t.ownedWebhook(actor, id); err != nil {
+		return nil, err
+	}
+	original, err := t.Repo.GetWebhookDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.SubscriptionID != id {
+		return nil, models.ErrWebhookDeliveryNotFound
+	}
+	now := time.Now()
+	redelivery := models.WebhookDelivery{
+		SubscriptionID: id,
+		Event:          original.Event,
+		Payload:        original.Payload,
+		Status:         models.WebhookDeliveryPending,
+		NextAttemptAt:  &now,
+		RedeliveryOf:   &original.ID,
+	}
+	deliveries := []models.WebhookDelivery{redelivery}
+	if err := t.Repo.CreateWebhookDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+// publishTaskEvent queues the event for every active subscription to it whose
+// owner can read the task.
+func (t *TaskServices) publishTaskEvent(event string, actorID uint, task *models.Task, changes []models.FieldChange) {
+	if t.webhooks == nil {
+		return
+	}
+	subs, err := t.Repo.GetActiveWebhooks(event)
+	if err != nil {
+		t.Logger.Printf("failed to load %s webhooks: %v", event, err)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+	ownerIDs := make([]uint, len(subs))
+	for i, sub := range subs {
+		ownerIDs[i] = sub.UserID
+	}
+	owners, err := t.Repo.GetUsersByIDs(uniqueIDs(ownerIDs))
+	if err != nil {
+		t.Logger.Printf("failed to load owners of %s webhooks: %v", event, err)
+		return
+	}
+	readers := make(map[uint]bool, len(owners))
+	for _, owner := range owners {
+		if owner.Disabled {
+			continue
+		}
+		allowed, err := t.canAccessTask(models.Actor{UserID: owner.ID, Role: owner.Role}, ActionReadTask, task)
+		if err != nil {
+			t.Logger.Printf("failed to check webhook access of user %d to task %d: %v", owner.ID, task.ID, err)
+			continue
+		}
+		readers[owner.ID] = allowed
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(WebhookPayload{Event: event, OccurredAt: now, ActorID: actorID, Task: task, Changes: changes})
+	if err != nil {
+		t.Logger.Printf("failed to encode %s webhook of task %d: %v", event, task.ID, err)
+		return
+	}
+	var deliveries []models.WebhookDelivery
+	for _, sub := range subs {
+		if !readers[sub.UserID] {
+			continue
+		}
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			Event:          event,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := t.Repo.CreateWebhookDeliveries(deliveries); err != nil {
+		t.Logger.Printf("failed to queue %s webhooks of task %d: %v", event, task.ID, err)
+	}
+}
+
+// historyEvents maps history actions to the webhook event they publish. Restoring
+// a task from the trash is published as an update.
+var historyEvents = map[string]string{
+	models.HistoryCreated:  models.WebhookTaskCreated,
+	models.HistoryUpdated:  models.WebhookTaskUpdated,
+	models.HistoryRestored: models.WebhookTaskUpdated,
+	models.HistoryDeleted:  models.WebhookTaskDeleted,
+}
+
+// publishHistory queues the webhook events of a history entry, with
+// task.status_changed next to the update when the status is part of it
+func (t *TaskServices) publishHistory(actorID uint, task *models.Task, action string, changes []models.FieldChange) {
+	event, ok := historyEvents[action]
+	if !ok || t.webhooks == nil {
+		return
+	}
+	t.publishTaskEvent(event, actorID, task, changes)
+	if event != models.WebhookTaskUpdated {
+		return
+	}
+	for _, change := range changes {
+		if change.Field == "status" {
+			t.publishTaskEvent(models.WebhookTaskStatusChanged, actorID, task, []models.FieldChange{change})
+		}
+	}
+}
+
+// DeliverWebhooks: Attempts the deliveries that are due and returns how many
+// succeeded. Failed attempts are retried with exponential backoff until the policy
+// gives up on them.
+func (t *TaskServices) DeliverWebhooks(ctx context.Context) (int, error) {
+	if t.webhooks == nil {
+		return 0, nil
+	}
+	now := time.Now()
+	deliveries, err := t.Repo.ClaimWebhookDeliveries(now, now.Add(t.webhookPolicy.Lease), t.webhookPolicy.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	subs := make(map[uint]*models.WebhookSubscription)
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		sub, ok := subs[delivery.SubscriptionID]
+		if !ok {
+			sub, err = t.Repo.GetWebhookByID(delivery.SubscriptionID)
+			if err != nil && !errors.Is(err, models.ErrWebhookNotFound) {
+				t.Logger.Printf("failed to load webhook %d: %v", delivery.SubscriptionID, err)
+				continue
+			}
+			subs[delivery.SubscriptionID] = sub
+		}
+		if t.attemptDelivery(ctx, sub, delivery) {
+			delivered++
+		}
+		if err := t.Repo.UpdateWebhookDelivery(delivery); err != nil {
+			t.Logger.Printf("failed to record webhook delivery %d: %v", delivery.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// attemptDelivery sends a delivery once and updates it with the outcome
+func (t *TaskServices) attemptDelivery(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) bool {
+	now := time.Now()
+	if sub == nil || !sub.Active {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = "subscription is inactive or deleted"
+		return false
+	}
+	delivery.Attempts++
+	status, err := t.webhooks.Send(ctx, webhook.Request{
+		URL:        sub.URL,
+		Secret:     sub.Secret,
+		Event:      delivery.Event,
+		DeliveryID: delivery.ID,
+		Body:       delivery.Payload,
+	})
+	delivery.LastStatusCode = status
+	if err == nil {
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return true
+	}
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= t.webhookPolicy.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return false
+	}
+	next := now.Add(t.webhookPolicy.backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	return false
+}
+
+// RunWebhookDeliveries delivers due webhooks every interval until ctx is done
+func RunWebhookDeliveries(ctx context.Context, svc inter.TaskServiceInter, interval time.Duration, logger *log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := svc.DeliverWebhooks(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Println("failed to deliver webhooks:", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
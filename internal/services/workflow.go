@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/models"
+)
+
+// Errors returned when a task status does not fit the workflow
+var (
+	ErrUnknownStatus        = errors.New("unknown status")
+	ErrTransitionNotAllowed = errors.New("status transition is not allowed")
+)
+
+// checkStatus validates the status of a new task, defaulting it to the first todo status
+func checkStatus(workflow *models.Workflow, task *models.Task) error {
+	if task.Status == "" {
+		task.Status = workflow.InitialStatus()
+	}
+	if _, ok := workflow.Status(task.Status); !ok {
+		return fmt.Errorf("%w %q", ErrUnknownStatus, task.Status)
+	}
+	return nil
+}
+
+// checkTransition validates a status change of an existing task. Tasks left in a
+// status the workflow no longer knows may move anywhere.
+func checkTransition(workflow *models.Workflow, from, to models.TaskStatus) error {
+	if from == to {
+		return nil
+	}
+	if _, ok := workflow.Status(to); !ok {
+		return fmt.Errorf("%w %q", ErrUnknownStatus, to)
+	}
+	if _, ok := workflow.Status(from); ok && !workflow.CanTransition(from, to) {
+		return fmt.Errorf("%w: %q -> %q", ErrTransitionNotAllowed, from, to)
+	}
+	return nil
+}
+
+// entering reports whether a status change moves a task into the category
+func entering(workflow *models.Workflow, from, to models.TaskStatus, category models.StatusCategory) bool {
+	return workflow.Category(to) == category && workflow.Category(from) != category
+}
+
+// GetWorkflow: Returns the statuses and transitions tasks follow
+func (t *TaskServices) GetWorkflow() (*models.Workflow, error) {
+	return t.Repo.GetWorkflow()
+}
+
+// UpdateWorkflow: Replaces the workflow, admins only. Statuses still in use cannot be dropped.
+func (t *TaskServices) UpdateWorkflow(actor models.Actor, workflow *models.Workflow) error {
+	if err := requireAdmin(actor); err != nil {
+		return err
+	}
+	if err := workflow.Validate(); err != nil {
+		return err
+	}
+	if err := t.Repo.ReplaceWorkflow(workflow); err != nil {
+		return err
+	}
+	// Blocked flags and progress depend on the categories
+	if t.redis != nil {
+		go func() {
+			for _, pattern := range []string{"tasks_list:*", "task:id=*"} {
+				if delErr := t.redis.DeleteByPattern(pattern); delErr != nil {
+					t.Logger.Println("Redis delete error:", delErr)
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// GetTaskTransitions: Returns the statuses a task the actor may read can move to
+func (t *TaskServices) GetTaskTransitions(actor models.Actor, id uint) (*models.TaskTransitions, error) {
+	task, err := t.authorizedTask(actor, ActionReadTask, id)
+	if err != nil {
+		return nil, err
+	}
+	workflow, err := t.Repo.GetWorkflow()
+	if err != nil {
+		return nil, err
+	}
+	transitions := &models.TaskTransitions{
+		Status:   task.Status,
+		Category: workflow.Category(task.Status),
+		Next:     workflow.NextStatuses(task.Status),
+	}
+	// Tasks in a status the workflow no longer knows may move anywhere
+	if _, ok := workflow.Status(task.Status); !ok {
+		transitions.Next = workflow.Statuses
+	}
+	return transitions, nil
+}
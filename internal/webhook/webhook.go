@@ -0,0 +1,164 @@
+// Package webhook signs and sends webhook requests. Receivers verify a request by
+// computing the HMAC-SHA256 of "<timestamp>.<body>" with the subscription secret
+// and comparing it with the signature header.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Headers of a webhook request
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxDrain is how much of a response is read before the connection is given up
+const maxDrain = 64 << 10
+
+// Sign computes the signature header value of a body sent at timestamp (Unix seconds)
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature header in constant time and rejects timestamps further
+// than tolerance from now, so captured requests cannot be replayed later
+func Verify(secret string, timestamp int64, body []byte, signature string, tolerance time.Duration) bool {
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// Request is one delivery attempt
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID uint
+	Body       []byte
+}
+
+// ErrForbiddenAddress is returned for receivers on loopback, private, link-local and
+// other non-public addresses that are not explicitly allowed
+var ErrForbiddenAddress = errors.New("webhook receiver address is not allowed")
+
+// Sender posts signed webhook requests
+type Sender struct {
+	Client *http.Client
+	// Now is the clock timestamps are taken from, time.Now when nil
+	Now func() time.Time
+}
+
+// NewSender creates a sender giving up on a receiver after timeout. Receivers must
+// be on public addresses unless they are in one of the allowed networks; the check
+// runs on the address actually dialled, so DNS answers cannot get around it.
+func NewSender(timeout time.Duration, allowed ...*net.IPNet) *Sender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !permitted(ip, allowed) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+	return &Sender{Client: &http.Client{
+		Timeout: timeout,
+		// No proxy, the dialled address has to be the receiver's
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		// A redirect could take the signed payload somewhere else
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}}
+}
+
+// permitted reports whether ip is public or in one of the allowed networks
+func permitted(ip net.IP, allowed []*net.IPNet) bool {
+	for _, network := range allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || sharedAddressSpace.Contains(ip))
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// ParseNetworks parses a comma separated list of CIDRs, such as the receivers an
+// operator trusts on the internal network
+func ParseNetworks(list string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, cidr := range strings.Split(list, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Send posts the request and returns the response status. Anything but a 2xx
+// response is an error.
+func (s *Sender) Send(ctx context.Context, r Request) (int, error) {
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+	timestamp := now().Unix()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-mgt-webhooks/1")
+	req.Header.Set(HeaderEvent, r.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(r.DeliveryID), 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(r.Secret, timestamp, r.Body))
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// The body is only read so the connection can be reused, it is never kept:
+	// receivers could be made to echo things the subscriber should not see
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrain))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver answered %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
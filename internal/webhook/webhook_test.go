@@ -0,0 +1,91 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/internal/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{"event":"task.created"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "sha256=fc53e1d22cb0ed2216fe98c535f28e2e668e9a812f23e87d18f07b966afb540a",
+		webhook.Sign("secret", 1700000000, []byte(`{"event":"task.created"}`)))
+}
+
+// loopback lets the senders of these tests reach httptest servers
+var loopback, _ = webhook.ParseNetworks("127.0.0.0/8, ::1/128")
+
+func TestSendAndVerify(t *testing.T) {
+	secret := "0123456789abcdef"
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+		verified = webhook.Verify(secret, timestamp, body, r.Header.Get(webhook.HeaderSignature), 5*time.Minute)
+		assert.Equal(t, "task.updated", r.Header.Get(webhook.HeaderEvent))
+		assert.Equal(t, "42", r.Header.Get(webhook.HeaderDelivery))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	status, err := webhook.NewSender(time.Second, loopback...).Send(context.Background(), webhook.Request{
+		URL: server.URL, Secret: secret, Event: "task.updated", DeliveryID: 42, Body: []byte(`{"id":1}`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.True(t, verified)
+
+	// Old requests are rejected even with a valid signature
+	old := time.Now().Add(-time.Hour).Unix()
+	assert.False(t, webhook.Verify(secret, old, []byte(`{}`), webhook.Sign(secret, old, []byte(`{}`)), 5*time.Minute))
+}
+
+func TestSend_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	status, err := webhook.NewSender(time.Second, loopback...).Send(context.Background(), webhook.Request{URL: server.URL, Body: []byte(`{}`)})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "boom")
+	assert.Equal(t, http.StatusInternalServerError, status)
+}
+
+func TestSend_ForbiddenAddress(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := webhook.NewSender(time.Second)
+	for _, url := range []string{server.URL, "http://169.254.169.254/latest/meta-data", "http://10.0.0.1:8080", "http://[::1]:9"} {
+		_, err := sender.Send(context.Background(), webhook.Request{URL: url, Body: []byte(`{}`)})
+		assert.ErrorIs(t, err, webhook.ErrForbiddenAddress, url)
+	}
+	assert.False(t, called)
+
+	// Names are checked by the address they resolve to
+	_, err := sender.Send(context.Background(), webhook.Request{URL: strings.Replace(server.URL, "127.0.0.1", "localhost", 1), Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, webhook.ErrForbiddenAddress)
+	assert.False(t, called)
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := webhook.ParseNetworks(" 10.1.0.0/16,, fd00::/8 ")
+	assert.NoError(t, err)
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "10.1.0.0/16", networks[0].String())
+
+	_, err = webhook.ParseNetworks("10.1.0.0")
+	assert.Error(t, err)
+}
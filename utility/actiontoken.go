@@ -0,0 +1,62 @@
+package utility
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Purposes of the single-use tokens mailed to users
+const (
+	PurposeVerifyEmail   = "verify_email"
+	PurposePasswordReset = "password_reset"
+	PurposeMFAChallenge  = "mfa_challenge"
+)
+
+// ActionClaim is carried by the single-use tokens sent in emails. Fingerprint
+// binds the token to the account state it was issued for, e.g. the current
+// password hash, so a reset token dies as soon as the password changes.
+type ActionClaim struct {
+	UserID      uint
+	Purpose     string
+	Fingerprint string
+	jwt.StandardClaims
+}
+
+// GenerateActionToken signs a token for purpose that expires after ttl
+func GenerateActionToken(keys *KeySet, purpose string, userID uint, state string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := &ActionClaim{
+		UserID:      userID,
+		Purpose:     purpose,
+		Fingerprint: Fingerprint(state),
+		StandardClaims: jwt.StandardClaims{
+			Id:        NewTokenID(),
+			ExpiresAt: now.Add(ttl).Unix(),
+			IssuedAt:  now.Unix(),
+		},
+	}
+	return keys.Sign(claims)
+}
+
+// ParseActionToken verifies an action token and checks it was issued for purpose
+func ParseActionToken(keys *KeySet, purpose, tokenStr string) (*ActionClaim, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &ActionClaim{}, keys.Keyfunc)
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid or expired token")
+	}
+	claims, ok := token.Claims.(*ActionClaim)
+	if !ok || claims.Purpose != purpose {
+		return nil, errors.New("invalid token purpose")
+	}
+	return claims, nil
+}
+
+// Fingerprint hashes account state so it can be embedded in a token without leaking it
+func Fingerprint(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:8])
+}
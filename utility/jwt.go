@@ -1,8 +1,11 @@
 package utility
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -10,42 +13,186 @@ import (
 	"github.com/golang-jwt/jwt"
 )
 
+// Token types carried in UserClaim.TokenType
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// Lifetimes of the tokens issued at login and on refresh
+const (
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
 // Userclaim struct defines the that jwt token holds
 type UserClaim struct {
 	UserID      uint
 	Username    string
 	Role        string
 	PayloadHash string
+	TokenType   string
+	// Family ties every access and refresh token of one login session together,
+	// so the whole session can be revoked at once.
+	Family string
+	// IssuedAtNano is the issue time in nanoseconds, iat only has whole seconds
+	// and a logout-all has to tell apart tokens issued in the same second
+	IssuedAtNano int64
 	jwt.StandardClaims
 }
 
-// GenerateToken will generate token for 5 hours with given data
-func GenerateToken(key, username string, userID uint) (string, error) {
-	expTime := time.Now().Add(time.Hour * 5).Unix()
+// RevokedBy reports whether a logout-all at cutoff covers the token, that is
+// whether the token was issued no later than cutoff
+func (c *UserClaim) RevokedBy(cutoff time.Time) bool {
+	return c.IssuedAtNano <= cutoff.UnixNano()
+}
+
+// TokenPair is what a successful login or refresh hands back to the client
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 
+	RefreshID        string    `json:"-"`
+	Family           string    `json:"-"`
+	RefreshExpiresAt time.Time `json:"-"`
+}
+
+// LoginResult is either a token pair, or an MFA challenge that has to be
+// answered at /login/mfa before any token is issued
+type LoginResult struct {
+	*TokenPair
+	MFARequired  bool   `json:"mfa_required,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+	MFAExpiresIn int64  `json:"mfa_expires_in,omitempty"`
+}
+
+// GenerateTokenPair issues a short-lived access token and a refresh token for the
+// same session family. An empty family starts a new session.
+func GenerateTokenPair(keys *KeySet, username string, userID uint, role, family string) (*TokenPair, error) {
+	if family == "" {
+		family = NewTokenID()
+	}
+	if role == "" {
+		role = "user"
+	}
+	now := time.Now()
+
+	access, _, err := signToken(keys, username, userID, role, AccessToken, family, now, AccessTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, refreshID, err := signToken(keys, username, userID, role, RefreshToken, family, now, RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		ExpiresIn:        int64(AccessTokenTTL.Seconds()),
+		RefreshID:        refreshID,
+		Family:           family,
+		RefreshExpiresAt: now.Add(RefreshTokenTTL),
+	}, nil
+}
+
+// signToken signs a single token and returns it together with its jti
+func signToken(keys *KeySet, username string, userID uint, role, tokenType, family string, now time.Time, ttl time.Duration) (string, string, error) {
+	jti := NewTokenID()
 	claims := &UserClaim{
-		UserID:      userID,
-		Username:    username,
-		Role:        "user",
-		PayloadHash: hashPayload(username, userID),
+		UserID:       userID,
+		Username:     username,
+		Role:         role,
+		PayloadHash:  hashPayload(username, userID),
+		TokenType:    tokenType,
+		Family:       family,
+		IssuedAtNano: now.UnixNano(),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expTime,
+			Id:        jti,
+			Issuer:    keys.policy.Issuer,
+			Audience:  keys.policy.Audience,
+			ExpiresAt: now.Add(ttl).Unix(),
+			NotBefore: now.Unix(),
 			Subject:   username,
-			IssuedAt:  time.Now().Unix(),
+			IssuedAt:  now.Unix(),
 		},
 	}
-	fmt.Println("Generated PayloadHash:", hashPayload(username, userID))
-
-	//Use the combination of email and key for signing
-	siginKey := []byte(key)
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := jwtToken.SignedString(siginKey)
+	signedToken, err := keys.Sign(claims)
 	if err != nil {
 		log.Printf("unable to generate token for user %v, err: %v", username, err.Error())
-		return "", err
+		return "", "", err
+	}
+	return signedToken, jti, nil
+}
+
+// ParseToken verifies the signature and claims of a token and returns them.
+// Failures are *TokenError values whose code tells the client what was wrong.
+func ParseToken(keys *KeySet, tokenStr string) (*UserClaim, error) {
+	// Time based claims are checked below with the configured leeway
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	token, err := parser.ParseWithClaims(tokenStr, &UserClaim{}, keys.Keyfunc)
+	if err != nil {
+		var vErr *jwt.ValidationError
+		switch {
+		case errors.As(err, &vErr) && vErr.Errors&jwt.ValidationErrorUnverifiable != 0:
+			return nil, NewTokenError(ErrCodeTokenUnknownKey, "token signing key is unknown or retired")
+		case errors.As(err, &vErr) && vErr.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+			return nil, NewTokenError(ErrCodeTokenSignature, "token signature is invalid")
+		default:
+			return nil, NewTokenError(ErrCodeTokenMalformed, "token is malformed")
+		}
+	}
+
+	claims, ok := token.Claims.(*UserClaim)
+	if !ok || !token.Valid {
+		return nil, NewTokenError(ErrCodeTokenMalformed, "invalid token claims")
+	}
+	if err := keys.policy.validate(claims, time.Now()); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validate checks the standard claims and that the payload hash matches the user claims
+func (p ClaimsPolicy) validate(claims *UserClaim, now time.Time) error {
+	leeway := int64(p.Leeway / time.Second)
+	unix := now.Unix()
+
+	if claims.ExpiresAt == 0 || unix > claims.ExpiresAt+leeway {
+		return NewTokenError(ErrCodeTokenExpired, "token has expired")
+	}
+	if claims.NotBefore != 0 && unix+leeway < claims.NotBefore {
+		return NewTokenError(ErrCodeTokenNotYetValid, "token is not valid yet")
+	}
+	if claims.IssuedAt != 0 && unix+leeway < claims.IssuedAt {
+		return NewTokenError(ErrCodeTokenNotYetValid, "token was issued in the future")
+	}
+	if p.Issuer != "" && claims.Issuer != p.Issuer {
+		return NewTokenError(ErrCodeTokenIssuer, "token issuer is not accepted")
+	}
+	if p.Audience != "" && claims.Audience != p.Audience {
+		return NewTokenError(ErrCodeTokenAudience, "token is not meant for this audience")
+	}
+	if claims.Id == "" {
+		return NewTokenError(ErrCodeTokenIDMissing, "token has no id")
+	}
+	expected := hashPayload(claims.Username, claims.UserID)
+	if subtle.ConstantTimeCompare([]byte(claims.PayloadHash), []byte(expected)) != 1 {
+		return NewTokenError(ErrCodeTokenPayload, "token payload does not match its user")
+	}
+	return nil
+}
+
+// NewTokenID returns a random identifier used for jti and session families
+func NewTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on supported platforms
+		panic(err)
 	}
-	return signedToken, nil
+	return hex.EncodeToString(b)
 }
 
 func hashPayload(email string, userID uint) string {
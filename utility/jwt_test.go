@@ -0,0 +1,119 @@
+package utility_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = utility.ClaimsPolicy{Issuer: "task-mgt", Audience: "task-mgt-api", Leeway: 30 * time.Second}
+
+func policyKeySet(policy utility.ClaimsPolicy) *utility.KeySet {
+	keys := utility.NewHMACKeySet("secret")
+	keys.SetClaimsPolicy(policy)
+	return keys
+}
+
+// resign parses the claims of a token, lets edit change them and signs them again
+func resign(t *testing.T, keys *utility.KeySet, token string, edit func(*utility.UserClaim)) string {
+	claims := &utility.UserClaim{}
+	_, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+	assert.NoError(t, err)
+	edit(claims)
+	signed, err := keys.Sign(claims)
+	assert.NoError(t, err)
+	return signed
+}
+
+func assertTokenError(t *testing.T, err error, code string) {
+	t.Helper()
+	var tokenErr *utility.TokenError
+	if assert.ErrorAs(t, err, &tokenErr) {
+		assert.Equal(t, code, tokenErr.Code)
+	}
+}
+
+func TestParseToken_StandardClaims(t *testing.T) {
+	keys := policyKeySet(testPolicy)
+	pair, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	claims, err := utility.ParseToken(keys, pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "task-mgt", claims.Issuer)
+	assert.Equal(t, "task-mgt-api", claims.Audience)
+	assert.NotEmpty(t, claims.Id)
+	assert.NotZero(t, claims.NotBefore)
+
+	otherIssuer := testPolicy
+	otherIssuer.Issuer = "someone-else"
+	_, err = utility.ParseToken(policyKeySet(otherIssuer), pair.AccessToken)
+	assertTokenError(t, err, utility.ErrCodeTokenIssuer)
+
+	otherAudience := testPolicy
+	otherAudience.Audience = "another-api"
+	_, err = utility.ParseToken(policyKeySet(otherAudience), pair.AccessToken)
+	assertTokenError(t, err, utility.ErrCodeTokenAudience)
+}
+
+func TestParseToken_ClockSkew(t *testing.T) {
+	keys := policyKeySet(testPolicy)
+	pair, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+	now := time.Now().Unix()
+
+	// Inside the leeway
+	token := resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.ExpiresAt = now - 10 })
+	_, err = utility.ParseToken(keys, token)
+	assert.NoError(t, err)
+	token = resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.NotBefore = now + 10 })
+	_, err = utility.ParseToken(keys, token)
+	assert.NoError(t, err)
+
+	// Beyond it
+	token = resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.ExpiresAt = now - 60 })
+	_, err = utility.ParseToken(keys, token)
+	assertTokenError(t, err, utility.ErrCodeTokenExpired)
+	token = resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.NotBefore = now + 60 })
+	_, err = utility.ParseToken(keys, token)
+	assertTokenError(t, err, utility.ErrCodeTokenNotYetValid)
+}
+
+func TestParseToken_PayloadAndID(t *testing.T) {
+	keys := policyKeySet(testPolicy)
+	pair, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	token := resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.UserID = 2 })
+	_, err = utility.ParseToken(keys, token)
+	assertTokenError(t, err, utility.ErrCodeTokenPayload)
+
+	token = resign(t, keys, pair.AccessToken, func(c *utility.UserClaim) { c.Id = "" })
+	_, err = utility.ParseToken(keys, token)
+	assertTokenError(t, err, utility.ErrCodeTokenIDMissing)
+
+	_, err = utility.ParseToken(utility.NewHMACKeySet("other"), pair.AccessToken)
+	assertTokenError(t, err, utility.ErrCodeTokenSignature)
+}
+
+func TestRevokedBy_LoginAfterLogoutAll(t *testing.T) {
+	keys := policyKeySet(testPolicy)
+	before, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	// Logout-all, then a new login within the same second
+	cutoff := time.Now()
+	after, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	claims, err := utility.ParseToken(keys, before.AccessToken)
+	assert.NoError(t, err)
+	assert.True(t, claims.RevokedBy(cutoff))
+
+	claims, err = utility.ParseToken(keys, after.AccessToken)
+	assert.NoError(t, err)
+	assert.False(t, claims.RevokedBy(cutoff))
+}
@@ -0,0 +1,341 @@
+package utility
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Supported signing algorithms
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// LegacyKeyID is the kid of the shared JWTKEY secret. Tokens signed before key
+// sets existed carry no kid and are verified with it.
+const LegacyKeyID = "default"
+
+// SigningKey is one key of a KeySet. A key with a RetireAt no longer signs and
+// only verifies until that moment, giving outstanding tokens a grace period.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	RetireAt  time.Time
+
+	secret  []byte
+	private interface{}
+	public  interface{}
+}
+
+// NewHMACKey creates a symmetric key. It can verify but is never published.
+func NewHMACKey(kid, secret string) *SigningKey {
+	return &SigningKey{ID: kid, Algorithm: AlgHS256, secret: []byte(secret)}
+}
+
+// NewRSAKey creates an RS256 key. A nil private key makes it verify-only.
+func NewRSAKey(kid string, private *rsa.PrivateKey, public *rsa.PublicKey) *SigningKey {
+	if private != nil {
+		public = &private.PublicKey
+	}
+	k := &SigningKey{ID: kid, Algorithm: AlgRS256, public: public}
+	if private != nil {
+		k.private = private
+	}
+	return k
+}
+
+// NewEd25519Key creates an EdDSA key. A nil private key makes it verify-only.
+func NewEd25519Key(kid string, private ed25519.PrivateKey, public ed25519.PublicKey) *SigningKey {
+	if private != nil {
+		public = private.Public().(ed25519.PublicKey)
+	}
+	k := &SigningKey{ID: kid, Algorithm: AlgEdDSA, public: public}
+	if private != nil {
+		k.private = private
+	}
+	return k
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgRS256:
+		return jwt.SigningMethodRS256
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func (k *SigningKey) signingKey() interface{} {
+	if k.Algorithm == AlgHS256 {
+		return k.secret
+	}
+	return k.private
+}
+
+func (k *SigningKey) verifyingKey() interface{} {
+	if k.Algorithm == AlgHS256 {
+		return k.secret
+	}
+	return k.public
+}
+
+func (k *SigningKey) canSign() bool {
+	if k.Algorithm == AlgHS256 {
+		return len(k.secret) > 0 || k.ID == LegacyKeyID
+	}
+	return k.private != nil
+}
+
+// retired reports whether the key is past its grace period
+func (k *SigningKey) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+// ClaimsPolicy sets the iss and aud claims of issued tokens, which parsed
+// tokens then have to match, and how much clock skew exp, nbf and iat tolerate.
+// Empty Issuer or Audience are neither set nor checked.
+type ClaimsPolicy struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// KeySet signs tokens with its active key and verifies tokens of any key that
+// is not retired yet, picked by the kid header.
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+	policy ClaimsPolicy
+}
+
+// SetClaimsPolicy sets the claims policy of tokens signed and parsed with the key set
+func (ks *KeySet) SetClaimsPolicy(policy ClaimsPolicy) {
+	ks.policy = policy
+}
+
+// NewKeySet creates a key set signing with the key whose ID is active
+func NewKeySet(active string, keys ...*SigningKey) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*SigningKey, len(keys))}
+	for _, k := range keys {
+		if _, dup := ks.keys[k.ID]; dup {
+			return nil, fmt.Errorf("duplicate key id %q", k.ID)
+		}
+		ks.keys[k.ID] = k
+	}
+	ks.active = ks.keys[active]
+	if ks.active == nil {
+		return nil, fmt.Errorf("active key %q is not in the key set", active)
+	}
+	if !ks.active.canSign() || !ks.active.RetireAt.IsZero() {
+		return nil, fmt.Errorf("active key %q cannot sign", active)
+	}
+	return ks, nil
+}
+
+// NewHMACKeySet is the single shared-secret key set used when no key file is configured
+func NewHMACKeySet(secret string) *KeySet {
+	ks, _ := NewKeySet(LegacyKeyID, NewHMACKey(LegacyKeyID, secret))
+	return ks
+}
+
+// Sign signs claims with the active key and sets the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.method(), claims)
+	token.Header["kid"] = ks.active.ID
+	return token.SignedString(ks.active.signingKey())
+}
+
+// Keyfunc resolves the verification key of a token for jwt.Parse. The token's
+// algorithm has to match the key's, so an RSA public key can never be used as
+// an HMAC secret.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = LegacyKeyID
+	}
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if key.retired(time.Now()) {
+		return nil, fmt.Errorf("signing key %q has been retired", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method")
+	}
+	return key.verifyingKey(), nil
+}
+
+// JWK is the public part of a key as published at /.well-known/jwks.json
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that still verify. Symmetric keys are never published.
+func (ks *KeySet) JWKS() JWKS {
+	now := time.Now()
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range ks.keys {
+		if k.retired(now) {
+			continue
+		}
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA", Kid: k.ID, Use: "sig", Alg: AlgRS256,
+				N: b64(pub.N.Bytes()),
+				E: b64(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP", Kid: k.ID, Use: "sig", Alg: AlgEdDSA,
+				Crv: "Ed25519", X: b64(pub),
+			})
+		}
+	}
+	return set
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// keyFile is the JSON layout of JWT_KEYS_FILE
+type keyFile struct {
+	Active string `json:"active"`
+	Keys   []struct {
+		Kid            string    `json:"kid"`
+		Alg            string    `json:"alg"`
+		PrivateKeyFile string    `json:"private_key_file"`
+		PublicKeyFile  string    `json:"public_key_file"`
+		SecretEnv      string    `json:"secret_env"`
+		RetireAt       time.Time `json:"retire_at"`
+	} `json:"keys"`
+}
+
+// LoadKeySet reads a key set file. When legacySecret is set it is added as a
+// verify-only HS256 key so tokens issued with JWTKEY keep working until it is unset.
+func LoadKeySet(path, legacySecret string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file keyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	}
+
+	var keys []*SigningKey
+	for _, entry := range file.Keys {
+		if entry.Kid == "" {
+			return nil, errors.New("every key needs a kid")
+		}
+		var key *SigningKey
+		switch entry.Alg {
+		case AlgHS256:
+			secret := os.Getenv(entry.SecretEnv)
+			if entry.SecretEnv == "" || secret == "" {
+				return nil, fmt.Errorf("key %q: secret_env must name a non-empty variable", entry.Kid)
+			}
+			key = NewHMACKey(entry.Kid, secret)
+		case AlgRS256:
+			key, err = loadRSAKey(entry.Kid, entry.PrivateKeyFile, entry.PublicKeyFile)
+		case AlgEdDSA:
+			key, err = loadEd25519Key(entry.Kid, entry.PrivateKeyFile, entry.PublicKeyFile)
+		default:
+			return nil, fmt.Errorf("key %q: unsupported alg %q", entry.Kid, entry.Alg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", entry.Kid, err)
+		}
+		key.RetireAt = entry.RetireAt
+		keys = append(keys, key)
+	}
+
+	if legacySecret != "" && file.Active != LegacyKeyID {
+		legacy := NewHMACKey(LegacyKeyID, legacySecret)
+		found := false
+		for _, k := range keys {
+			found = found || k.ID == LegacyKeyID
+		}
+		if !found {
+			// Verify-only: it is never the active key here
+			keys = append(keys, legacy)
+		}
+	}
+	return NewKeySet(file.Active, keys...)
+}
+
+func loadRSAKey(kid, privateFile, publicFile string) (*SigningKey, error) {
+	if privateFile != "" {
+		pem, err := os.ReadFile(privateFile)
+		if err != nil {
+			return nil, err
+		}
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		return NewRSAKey(kid, private, nil), nil
+	}
+	pem, err := os.ReadFile(publicFile)
+	if err != nil {
+		return nil, err
+	}
+	public, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSAKey(kid, nil, public), nil
+}
+
+func loadEd25519Key(kid, privateFile, publicFile string) (*SigningKey, error) {
+	if privateFile != "" {
+		pem, err := os.ReadFile(privateFile)
+		if err != nil {
+			return nil, err
+		}
+		private, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		edPrivate, ok := private.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("not an Ed25519 private key")
+		}
+		return NewEd25519Key(kid, edPrivate, nil), nil
+	}
+	pem, err := os.ReadFile(publicFile)
+	if err != nil {
+		return nil, err
+	}
+	public, err := jwt.ParseEdPublicKeyFromPEM(pem)
+	if err != nil {
+		return nil, err
+	}
+	return NewEd25519Key(kid, nil, public.(ed25519.PublicKey)), nil
+}
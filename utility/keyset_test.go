@@ -0,0 +1,125 @@
+package utility_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeySet_AsymmetricRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	for _, key := range []*utility.SigningKey{
+		utility.NewRSAKey("rsa-1", rsaKey, nil),
+		utility.NewEd25519Key("ed-1", edKey, nil),
+	} {
+		keys, err := utility.NewKeySet(key.ID, key)
+		assert.NoError(t, err)
+
+		pair, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+		assert.NoError(t, err)
+
+		claims, err := utility.ParseToken(keys, pair.AccessToken)
+		assert.NoError(t, err, key.Algorithm)
+		assert.Equal(t, uint(1), claims.UserID)
+
+		token, _, err := new(jwt.Parser).ParseUnverified(pair.AccessToken, &utility.UserClaim{})
+		assert.NoError(t, err)
+		assert.Equal(t, key.ID, token.Header["kid"])
+		assert.Equal(t, key.Algorithm, token.Method.Alg())
+	}
+}
+
+func TestKeySet_RotationGracePeriod(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	before, err := utility.NewKeySet("old", utility.NewEd25519Key("old", oldKey, nil))
+	assert.NoError(t, err)
+	pair, err := utility.GenerateTokenPair(before, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	// Rotated: the old key only verifies until it retires
+	graceful := utility.NewEd25519Key("old", oldKey, nil)
+	graceful.RetireAt = time.Now().Add(time.Hour)
+	after, err := utility.NewKeySet("new", utility.NewEd25519Key("new", newKey, nil), graceful)
+	assert.NoError(t, err)
+	_, err = utility.ParseToken(after, pair.AccessToken)
+	assert.NoError(t, err)
+
+	expired := utility.NewEd25519Key("old", oldKey, nil)
+	expired.RetireAt = time.Now().Add(-time.Minute)
+	retired, err := utility.NewKeySet("new", utility.NewEd25519Key("new", newKey, nil), expired)
+	assert.NoError(t, err)
+	_, err = utility.ParseToken(retired, pair.AccessToken)
+	assert.Error(t, err)
+}
+
+func TestKeySet_RejectsAlgorithmMismatch(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keys, err := utility.NewKeySet("rsa-1", utility.NewRSAKey("rsa-1", rsaKey, nil))
+	assert.NoError(t, err)
+
+	// HS256 token claiming the RSA kid, signed with the public modulus as secret
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &utility.UserClaim{UserID: 1, TokenType: "access"})
+	token.Header["kid"] = "rsa-1"
+	forged, err := token.SignedString(rsaKey.PublicKey.N.Bytes())
+	assert.NoError(t, err)
+
+	_, err = utility.ParseToken(keys, forged)
+	assert.Error(t, err)
+}
+
+func TestKeySet_LegacyTokensWithoutKid(t *testing.T) {
+	keys := utility.NewHMACKeySet("secret")
+	pair, err := utility.GenerateTokenPair(keys, "user@example.com", 1, "user", "")
+	assert.NoError(t, err)
+
+	// Re-sign the same claims without a kid header, as tokens were before key sets
+	claims := &utility.UserClaim{}
+	_, _, err = new(jwt.Parser).ParseUnverified(pair.AccessToken, claims)
+	assert.NoError(t, err)
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	_, err = utility.ParseToken(keys, legacy)
+	assert.NoError(t, err)
+}
+
+func TestKeySet_JWKSPublishesOnlyPublicKeys(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	retired := utility.NewEd25519Key("ed-old", edKey, nil)
+	retired.RetireAt = time.Now().Add(-time.Minute)
+
+	keys, err := utility.NewKeySet("rsa-1",
+		utility.NewRSAKey("rsa-1", rsaKey, nil),
+		utility.NewHMACKey(utility.LegacyKeyID, "secret"),
+		retired,
+	)
+	assert.NoError(t, err)
+
+	set := keys.JWKS()
+	assert.Len(t, set.Keys, 1)
+	assert.Equal(t, "rsa-1", set.Keys[0].Kid)
+	assert.Equal(t, "RSA", set.Keys[0].Kty)
+	assert.Equal(t, "AQAB", set.Keys[0].E)
+}
+
+func TestNewKeySet_ActiveKeyMustSign(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, err := utility.NewKeySet("rsa-1", utility.NewRSAKey("rsa-1", nil, &rsaKey.PublicKey))
+	assert.Error(t, err)
+
+	_, err = utility.NewKeySet("missing", utility.NewHMACKey("a", "secret"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package utility
+
+// Machine-readable reasons a token or API key is rejected, returned to clients
+// as the "code" field next to "error"
+const (
+	ErrCodeTokenMissing     = "token_missing"
+	ErrCodeTokenMalformed   = "token_malformed"
+	ErrCodeTokenUnknownKey  = "token_key_unknown"
+	ErrCodeTokenSignature   = "token_signature_invalid"
+	ErrCodeTokenExpired     = "token_expired"
+	ErrCodeTokenNotYetValid = "token_not_yet_valid"
+	ErrCodeTokenIssuer      = "token_issuer_invalid"
+	ErrCodeTokenAudience    = "token_audience_invalid"
+	ErrCodeTokenIDMissing   = "token_id_missing"
+	ErrCodeTokenPayload     = "token_payload_mismatch"
+	ErrCodeTokenType        = "token_type_invalid"
+	ErrCodeTokenRevoked     = "token_revoked"
+	ErrCodeUserNotFound     = "user_not_found"
+	ErrCodeUserDisabled     = "user_disabled"
+	ErrCodeAPIKeyInvalid    = "api_key_invalid"
+)
+
+// TokenError is an authentication failure with a stable code
+type TokenError struct {
+	Code    string
+	Message string
+}
+
+func (e *TokenError) Error() string {
+	return e.Message
+}
+
+// NewTokenError creates a TokenError
+func NewTokenError(code, message string) *TokenError {
+	return &TokenError{Code: code, Message: message}
+}
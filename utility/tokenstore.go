@@ -0,0 +1,145 @@
+package utility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	redis "github.com/go-redis/redis/v8"
+)
+
+// ErrTokenStoreUnavailable is returned when no redis client backs the store
+var ErrTokenStoreUnavailable = errors.New("token store is not configured")
+
+// TokenStore keeps server-side token state in redis: the jti denylist,
+// revoked session families, per-user "logout everywhere" markers and the
+// set of refresh tokens that may still be exchanged.
+type TokenStore struct {
+	Client *redis.Client
+}
+
+// NewTokenStore creates a store on top of an existing redis client
+func NewTokenStore(client *redis.Client) *TokenStore {
+	return &TokenStore{Client: client}
+}
+
+func revokedTokenKey(jti string) string     { return "revoked_jti:" + jti }
+func revokedFamilyKey(family string) string { return "revoked_family:" + family }
+func revokedBeforeKey(userID uint) string   { return fmt.Sprintf("revoked_before:user:%d", userID) }
+func refreshTokenKey(jti string) string     { return "refresh:" + jti }
+func disabledUserKey(userID uint) string    { return fmt.Sprintf("disabled_user:%d", userID) }
+func usedTokenKey(jti string) string        { return "used_token:" + jti }
+
+func (s *TokenStore) ready() error {
+	if s == nil || s.Client == nil {
+		return ErrTokenStoreUnavailable
+	}
+	return nil
+}
+
+// RevokeToken puts a single jti on the denylist until the token would expire anyway
+func (s *TokenStore) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.Client.Set(ctx, revokedTokenKey(jti), 1, ttl).Err()
+}
+
+// RevokeFamily revokes every token of a login session
+func (s *TokenStore) RevokeFamily(ctx context.Context, family string) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.Client.Set(ctx, revokedFamilyKey(family), 1, RefreshTokenTTL).Err()
+}
+
+// RevokeAllForUser revokes every token issued to the user up to now. The cutoff
+// is kept in nanoseconds so a login right after it is not caught.
+func (s *TokenStore) RevokeAllForUser(ctx context.Context, userID uint) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	return s.Client.Set(ctx, revokedBeforeKey(userID), time.Now().UnixNano(), RefreshTokenTTL).Err()
+}
+
+// SetUserDisabled marks or unmarks a user as disabled. Disabled users are
+// rejected by IsRevoked no matter when their token was issued.
+func (s *TokenStore) SetUserDisabled(ctx context.Context, userID uint, disabled bool) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	if disabled {
+		return s.Client.Set(ctx, disabledUserKey(userID), 1, 0).Err()
+	}
+	return s.Client.Del(ctx, disabledUserKey(userID)).Err()
+}
+
+// IsRevoked reports whether the token was revoked by jti, session family, a logout-all
+// or because its user has been disabled
+func (s *TokenStore) IsRevoked(ctx context.Context, claims *UserClaim) (bool, error) {
+	if err := s.ready(); err != nil {
+		return false, err
+	}
+
+	n, err := s.Client.Exists(ctx, revokedTokenKey(claims.Id), revokedFamilyKey(claims.Family), disabledUserKey(claims.UserID)).Result()
+	if err != nil {
+		return false, err
+	}
+	if n > 0 {
+		return true, nil
+	}
+
+	before, err := s.Client.Get(ctx, revokedBeforeKey(claims.UserID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	cutoff, err := strconv.ParseInt(before, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return claims.RevokedBy(time.Unix(0, cutoff)), nil
+}
+
+// SaveRefreshToken records a freshly issued refresh token as exchangeable
+func (s *TokenStore) SaveRefreshToken(ctx context.Context, pair *TokenPair, userID uint) error {
+	if err := s.ready(); err != nil {
+		return err
+	}
+	value := fmt.Sprintf("%d:%s", userID, pair.Family)
+	return s.Client.Set(ctx, refreshTokenKey(pair.RefreshID), value, time.Until(pair.RefreshExpiresAt)).Err()
+}
+
+// ConsumeRefreshToken atomically removes a refresh token. It returns false when the
+// token had already been exchanged, which callers must treat as token reuse.
+func (s *TokenStore) ConsumeRefreshToken(ctx context.Context, jti string) (bool, error) {
+	if err := s.ready(); err != nil {
+		return false, err
+	}
+	n, err := s.Client.Del(ctx, refreshTokenKey(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// MarkUsed records a single-use token as spent. It returns false when the token
+// had been used before.
+func (s *TokenStore) MarkUsed(ctx context.Context, jti string, expiresAt time.Time) (bool, error) {
+	if err := s.ready(); err != nil {
+		return false, err
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return false, nil
+	}
+	return s.Client.SetNX(ctx, usedTokenKey(jti), 1, ttl).Result()
+}
@@ -0,0 +1,42 @@
+package utility_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ratheeshkumar25/task-mgt/utility"
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B, SHA1 secret "12345678901234567890", truncated to 6 digits
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		code, err := utility.TOTPCode(secret, utility.TOTPStep(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, "time %d", unix)
+	}
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	secret, err := utility.GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	previous, _ := utility.TOTPCode(secret, utility.TOTPStep(now)-1)
+	stale, _ := utility.TOTPCode(secret, utility.TOTPStep(now)-3)
+
+	step, ok := utility.ValidateTOTP(secret, previous, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, utility.TOTPStep(now)-1, step)
+
+	_, ok = utility.ValidateTOTP(secret, stale, now, 1)
+	assert.False(t, ok)
+}